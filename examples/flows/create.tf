@@ -30,4 +30,28 @@ output "new_automated_flow" {
         flow_id = truora_flow.new_automated_flow_inline.flow_id
         name = truora_flow.new_automated_flow_inline.name
     }
-}
\ No newline at end of file
+}
+
+data "truora_flow_document" "market_flow_document" {
+    source_json = file("samples/new_automated_flow.json")
+
+    name = "Market flow"
+
+    verification_json = [
+        data.truora_verification_document.phone_verification.json,
+    ]
+
+    override_json = jsonencode({
+        config = {
+            lang = "en"
+        }
+    })
+}
+
+data "truora_verification_document" "phone_verification" {
+    name = "phone_verification"
+
+    steps {
+        type = "phone-verification"
+    }
+}
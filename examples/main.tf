@@ -8,7 +8,9 @@ terraform {
 }
 
 provider "truora" {
-  api_server = "https://api.identity.truora.com"
+  endpoints {
+    identity = "https://api.identity.truora.com"
+  }
 }
 
 
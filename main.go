@@ -1,16 +1,96 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
 	"terraform-provider-truora/truora"
+	truoraclient "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/export"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 )
 
+const providerAddress = "truora.com/local/truora"
+
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return truora.Provider()
-		},
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	var debug bool
+
+	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	shutdownTracing, err := truora.StartTracing(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = serve(ctx, debug)
+
+	// shut down before log.Fatal, which exits without running deferred calls
+	shutdownTracing(ctx)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// serve runs the muxed SDK and framework providers until Terraform stops them
+func serve(ctx context.Context, debug bool) error {
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, truora.ProviderServers()...)
+	if err != nil {
+		return err
+	}
+
+	var serveOpts []tf5server.ServeOpt
+
+	if debug {
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+
+	return tf5server.Serve(providerAddress, muxServer.ProviderServer, serveOpts...)
+}
+
+// runExport writes the flows of the account as Terraform configuration. The
+// client is configured from the same environment variables as the provider.
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+
+	outDir := flags.String("out", ".", "directory where the .tf files are written")
+	format := flags.String("format", string(export.FormatHCL), "how flow documents are written: hcl for truora_flow_document blocks, json for jsonencode")
+	concurrency := flags.Int("concurrency", truoraclient.DefaultGetFlowsConcurrency, "how many flows are fetched at once")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := truoraclient.NewClient(truoraclient.WithUserAgent(fmt.Sprintf("%s/%s", truoraclient.DefaultUserAgent, truora.Version)))
+	if err != nil {
+		return err
+	}
+
+	paths, err := export.Flows(context.Background(), client, export.Options{
+		OutDir:      *outDir,
+		Format:      export.Format(*format),
+		Concurrency: *concurrency,
 	})
+
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+
+	return err
 }
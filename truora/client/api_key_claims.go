@@ -0,0 +1,89 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrMalformedAPIKey = fmt.Errorf("API key is not a valid JWT")
+
+// APIKeyClaims are the claims Truora encodes in its API keys
+type APIKeyClaims struct {
+	ClientID  string
+	KeyName   string
+	KeyType   string
+	Username  string
+	Grants    []string
+	ExpiresAt *time.Time
+}
+
+type apiKeyClaims struct {
+	ClientID  string          `json:"client_id"`
+	KeyName   string          `json:"key_name"`
+	KeyType   string          `json:"key_type"`
+	Username  string          `json:"username"`
+	Grant     json.RawMessage `json:"grant"`
+	ExpiresAt int64           `json:"exp"`
+}
+
+// HasGrant reports whether the key was issued with grant
+func (c *APIKeyClaims) HasGrant(grant string) bool {
+	for _, g := range c.Grants {
+		if g == grant {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseAPIKeyClaims decodes the claims of an API key. The signature is not
+// verified, only Truora can do that, so the claims are informative only.
+func ParseAPIKeyClaims(apiKey string) (*APIKeyClaims, error) {
+	parts := strings.Split(apiKey, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedAPIKey
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedAPIKey, err)
+	}
+
+	var raw apiKeyClaims
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedAPIKey, err)
+	}
+
+	claims := &APIKeyClaims{
+		ClientID: raw.ClientID,
+		KeyName:  raw.KeyName,
+		KeyType:  raw.KeyType,
+		Username: raw.Username,
+	}
+
+	// the grant claim is a single string in older keys and a list in newer ones
+	if len(raw.Grant) > 0 {
+		var grant string
+		if err := json.Unmarshal(raw.Grant, &grant); err == nil {
+			claims.Grants = []string{grant}
+		} else if err := json.Unmarshal(raw.Grant, &claims.Grants); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrMalformedAPIKey, err)
+		}
+	}
+
+	if raw.ExpiresAt != 0 {
+		expiresAt := time.Unix(raw.ExpiresAt, 0).UTC()
+		claims.ExpiresAt = &expiresAt
+	}
+
+	return claims, nil
+}
+
+// APIKeyClaims decodes the claims of the API key used by the client
+func (c *TruoraClient) APIKeyClaims() (*APIKeyClaims, error) {
+	return ParseAPIKeyClaims(c.APIKey)
+}
@@ -0,0 +1,101 @@
+package client_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+// unsignedAPIKey builds an API key with the given claims, the signature isn't
+// checked by ParseAPIKeyClaims
+func unsignedAPIKey(claims string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+}
+
+func TestParseAPIKeyClaims(t *testing.T) {
+	expiresAt := time.Unix(1700000000, 0).UTC()
+
+	cases := []struct {
+		name     string
+		apiKey   string
+		expected *truora.APIKeyClaims
+		err      error
+	}{
+		{
+			name:   "list of grants",
+			apiKey: unsignedAPIKey(`{"client_id":"TCI1","key_name":"ci","key_type":"backend","grant":["digital-identity","checks"],"exp":1700000000}`),
+			expected: &truora.APIKeyClaims{
+				ClientID:  "TCI1",
+				KeyName:   "ci",
+				KeyType:   "backend",
+				Grants:    []string{"digital-identity", "checks"},
+				ExpiresAt: &expiresAt,
+			},
+		},
+		{
+			name:   "single grant",
+			apiKey: unsignedAPIKey(`{"key_name":"ci","grant":"digital-identity","exp":1700000000}`),
+			expected: &truora.APIKeyClaims{
+				KeyName:   "ci",
+				Grants:    []string{"digital-identity"},
+				ExpiresAt: &expiresAt,
+			},
+		},
+		{
+			name:     "missing exp",
+			apiKey:   unsignedAPIKey(`{"key_name":"ci","grant":"digital-identity"}`),
+			expected: &truora.APIKeyClaims{KeyName: "ci", Grants: []string{"digital-identity"}},
+		},
+		{
+			name:     "missing grant",
+			apiKey:   unsignedAPIKey(`{"key_name":"ci","exp":1700000000}`),
+			expected: &truora.APIKeyClaims{KeyName: "ci", ExpiresAt: &expiresAt},
+		},
+		{
+			name:   "not a JWT",
+			apiKey: "plain-key",
+			err:    truora.ErrMalformedAPIKey,
+		},
+		{
+			name:   "payload isn't base64",
+			apiKey: "header.%%%.signature",
+			err:    truora.ErrMalformedAPIKey,
+		},
+		{
+			name:   "payload isn't JSON",
+			apiKey: unsignedAPIKey(`not json`),
+			err:    truora.ErrMalformedAPIKey,
+		},
+		{
+			name:   "grant of the wrong type",
+			apiKey: unsignedAPIKey(`{"grant":42}`),
+			err:    truora.ErrMalformedAPIKey,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := truora.ParseAPIKeyClaims(tc.apiKey)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(claims, tc.expected) {
+				t.Fatalf("expected %+v, got %+v", tc.expected, claims)
+			}
+		})
+	}
+}
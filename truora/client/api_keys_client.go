@@ -0,0 +1,209 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	WebKeyType           = "web"
+	DigitalIdentityGrant = "digital-identity"
+)
+
+// APIKeyRequest contains the fields used to create an API key in the account API
+type APIKeyRequest struct {
+	KeyName     string
+	KeyType     string
+	Grants      []string
+	FlowID      string
+	Country     string
+	RedirectURL string
+	ExpiresIn   int64
+	Expiration  *time.Time
+}
+
+// WebIntegrationTokenRequest contains the fields used to mint a web key that
+// starts a Digital Identity process for a flow in the browser
+type WebIntegrationTokenRequest struct {
+	FlowID      string
+	Country     string
+	RedirectURL string
+	// ExpiresIn is the lifetime of the token in seconds, zero uses the API default
+	ExpiresIn int64
+}
+
+// APIKey is an API key returned by the account API, Key is only filled
+// when the key is created
+type APIKey struct {
+	KeyName    string     `json:"key_name"`
+	KeyType    string     `json:"key_type"`
+	Grants     []string   `json:"grants,omitempty"`
+	FlowID     string     `json:"flow_id,omitempty"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+	Key        string     `json:"api_key,omitempty"`
+}
+
+func (r *APIKeyRequest) formValues() url.Values {
+	values := url.Values{}
+	values.Set("key_name", r.KeyName)
+	values.Set("key_type", r.KeyType)
+
+	for _, grant := range r.Grants {
+		values.Add("grant", grant)
+	}
+
+	if r.FlowID != "" {
+		values.Set("flow_id", r.FlowID)
+	}
+
+	if r.Country != "" {
+		values.Set("country", r.Country)
+	}
+
+	if r.RedirectURL != "" {
+		values.Set("redirect_url", r.RedirectURL)
+	}
+
+	if r.ExpiresIn > 0 {
+		values.Set("expires_in", strconv.FormatInt(r.ExpiresIn, 10))
+	}
+
+	if r.Expiration != nil {
+		values.Set("expiration", r.Expiration.Format(time.RFC3339))
+	}
+
+	return values
+}
+
+func (c *TruoraClient) CreateAPIKey(ctx context.Context, apiKey *APIKeyRequest) (*APIKey, error) {
+	client := c.HTTPClient
+
+	reader := strings.NewReader(apiKey.formValues().Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/v1/api-keys", c.Endpoints.Account), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error creating API key: %s\n%s", resp.Status, stringBody)
+	}
+
+	var apiKeyResponse APIKey
+	if err := json.NewDecoder(resp.Body).Decode(&apiKeyResponse); err != nil {
+		return nil, err
+	}
+
+	return &apiKeyResponse, nil
+}
+
+// CreateWebIntegrationToken mints a web key for a flow, the returned key is the
+// token used to open the process in the identity web application
+func (c *TruoraClient) CreateWebIntegrationToken(ctx context.Context, tokenRequest *WebIntegrationTokenRequest) (*APIKey, error) {
+	keyName := fmt.Sprintf("web-%s-%d", tokenRequest.FlowID, time.Now().UnixNano())
+
+	apiKey, err := c.CreateAPIKey(ctx, &APIKeyRequest{
+		KeyName:     keyName,
+		KeyType:     WebKeyType,
+		Grants:      []string{DigitalIdentityGrant},
+		FlowID:      tokenRequest.FlowID,
+		Country:     tokenRequest.Country,
+		RedirectURL: tokenRequest.RedirectURL,
+		ExpiresIn:   tokenRequest.ExpiresIn,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the key name is needed to delete the token later
+	if apiKey.KeyName == "" {
+		apiKey.KeyName = keyName
+	}
+
+	return apiKey, nil
+}
+
+// ProcessURL returns the URL that starts a Digital Identity process with a web token
+func (c *TruoraClient) ProcessURL(token string) string {
+	return fmt.Sprintf("%s/?token=%s", c.Endpoints.Web, url.QueryEscape(token))
+}
+
+func (c *TruoraClient) GetAPIKey(ctx context.Context, keyName string) (*APIKey, error) {
+	client := c.HTTPClient
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/v1/api-keys/%s", c.Endpoints.Account, url.PathEscape(keyName)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error getting API key: %s\n%s", resp.Status, stringBody)
+	}
+
+	var apiKey APIKey
+	if err := json.NewDecoder(resp.Body).Decode(&apiKey); err != nil {
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
+
+func (c *TruoraClient) DeleteAPIKey(ctx context.Context, keyName string) error {
+	client := c.HTTPClient
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/v1/api-keys/%s", c.Endpoints.Account, url.PathEscape(keyName)), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return fmt.Errorf("error deleting API key: %s\n%s", resp.Status, stringBody)
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	CassetteEnvironmentVariableName     = "TRUORA_CASSETTE"
+	CassetteModeEnvironmentVariableName = "TRUORA_CASSETTE_MODE"
+)
+
+// CassetteMode selects whether a cassette records real API calls or replays
+// recorded ones
+type CassetteMode string
+
+const (
+	CassetteModeRecord CassetteMode = "record"
+	CassetteModeReplay CassetteMode = "replay"
+)
+
+var ErrNoRecordedInteraction = fmt.Errorf("no recorded interaction matches the request")
+
+// cassetteLockTimeout bounds how long a recording waits for another process,
+// like a plan and an apply recording to the same file, to release the cassette
+const cassetteLockTimeout = 30 * time.Second
+
+// Cassette is the file format of recorded interactions
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response, with credentials and
+// PII redacted
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+type CassetteRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// CassetteTransport is an http.RoundTripper that records interactions to a
+// cassette file or replays them from it. Recorded interactions are appended
+// to the file, so every client and process of a run, like the plan and the
+// apply, share one cassette. Replayed requests are matched by method, path
+// and query, in the order they were recorded, the host is ignored so a
+// cassette can be replayed against any endpoint.
+type CassetteTransport struct {
+	path string
+	mode CassetteMode
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+	loadErr  error
+}
+
+// NewCassetteTransport returns a transport for the cassette at path. In replay
+// mode the cassette is loaded right away, next is only used when recording.
+func NewCassetteTransport(path string, mode CassetteMode, next http.RoundTripper) *CassetteTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	t := &CassetteTransport{
+		path: path,
+		mode: mode,
+		next: next,
+	}
+
+	if mode == CassetteModeReplay {
+		t.cassette, t.loadErr = loadCassette(path)
+		if t.cassette != nil {
+			t.used = make([]bool, len(t.cassette.Interactions))
+		}
+	}
+
+	return t
+}
+
+// WithCassette records or replays every request of the client using the
+// cassette at path
+func WithCassette(path string, mode CassetteMode) TruoraClientOption {
+	return WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return NewCassetteTransport(path, mode, next)
+	})
+}
+
+func (t *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.mode == CassetteModeRecord {
+		return t.record(req)
+	}
+
+	return t.replay(req)
+}
+
+func (t *CassetteTransport) record(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := &Interaction{
+		Request: CassetteRequest{
+			Method:  req.Method,
+			URL:     redactURL(req.URL),
+			Headers: redactHeaders(req.Header),
+			Body:    string(redactBody(req.Header.Get("Content-Type"), requestBody)),
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    redactHeaders(resp.Header),
+			Body:       string(redactBody(resp.Header.Get("Content-Type"), responseBody)),
+		},
+	}
+
+	// saved after every interaction so a failed apply still leaves a cassette
+	if err := t.appendInteraction(interaction); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// appendInteraction adds interaction to the cassette file, holding a lock file
+// so concurrent clients and processes don't overwrite each other
+func (t *CassetteTransport) appendInteraction(interaction *Interaction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	unlock, err := lockCassette(t.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	cassette, err := loadCassette(t.path)
+	if errors.Is(err, os.ErrNotExist) {
+		cassette, err = &Cassette{}, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cassette.Interactions = append(cassette.Interactions, interaction)
+
+	return saveCassette(t.path, cassette)
+}
+
+func (t *CassetteTransport) replay(req *http.Request) (*http.Response, error) {
+	if t.loadErr != nil {
+		return nil, t.loadErr
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	target := redactURL(req.URL)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, interaction := range t.cassette.Interactions {
+		if t.used[i] || interaction.Request.Method != req.Method || !sameRequestURI(interaction.Request.URL, target) {
+			continue
+		}
+
+		t.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Headers.Clone(),
+			Body:          io.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoRecordedInteraction, req.Method, target)
+}
+
+func sameRequestURI(recorded, target string) bool {
+	recordedURL, err := url.Parse(recorded)
+	if err != nil {
+		return false
+	}
+
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	return recordedURL.RequestURI() == targetURL.RequestURI()
+}
+
+// readRequestBody reads the body of req and puts back a copy so it can
+// still be sent
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func loadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error loading cassette: %w", err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("error decoding cassette %s: %w", path, err)
+	}
+
+	return &cassette, nil
+}
+
+// saveCassette writes the cassette to a temporary file and renames it, so a
+// reader never sees a partially written cassette
+func saveCassette(path string, cassette *Cassette) error {
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error saving cassette: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("error saving cassette: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error saving cassette: %w", err)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("error saving cassette: %w", err)
+	}
+
+	return nil
+}
+
+// lockCassette creates the lock file of the cassette at path, waiting while
+// another client holds it, and returns the function that removes it
+func lockCassette(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(cassetteLockTimeout)
+
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			file.Close()
+
+			return func() { os.Remove(lockPath) }, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("error locking cassette: %w", err)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("error locking cassette: %s is held by another process, remove it if no recording is running", lockPath)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,207 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := server.NewClient(truora.WithCassette(cassettePath, truora.CassetteModeRecord))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := recorder.CreateFlow(context.Background(), &truora.IdentityProcessFlow{
+		Name: "cassette",
+		Type: "permanent",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cassette, err := os.ReadFile(cassettePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(cassette), truoratest.APIKey) {
+		t.Fatal("cassette contains the API key")
+	}
+
+	server.Close()
+
+	replayer, err := truora.NewClient(
+		truora.WithAPIKey("replay-key"),
+		truora.WithEndpoints(truora.Endpoints{Identity: "http://replay.invalid"}),
+		truora.WithCassette(cassettePath, truora.CassetteModeReplay),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, err := replayer.CreateFlow(context.Background(), &truora.IdentityProcessFlow{
+		Name: "cassette",
+		Type: "permanent",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if replayed.FlowID != created.FlowID {
+		t.Fatalf("expected flow %s, got %s", created.FlowID, replayed.FlowID)
+	}
+
+	_, err = replayer.CreateFlow(context.Background(), &truora.IdentityProcessFlow{Name: "cassette"})
+	if !errors.Is(err, truora.ErrNoRecordedInteraction) {
+		t.Fatalf("expected ErrNoRecordedInteraction, got %v", err)
+	}
+}
+
+func TestCassetteRecordAppends(t *testing.T) {
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+
+	// each client stands for a process of the run, like the plan and the
+	// apply, or the two providers served by the mux
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		flowIDs []string
+	)
+
+	for i := 0; i < 4; i++ {
+		recorder, err := server.NewClient(truora.WithCassette(cassettePath, truora.CassetteModeRecord))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			created, err := recorder.CreateFlow(context.Background(), &truora.IdentityProcessFlow{
+				Name: "cassette",
+				Type: "permanent",
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			mu.Lock()
+			flowIDs = append(flowIDs, created.FlowID)
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	if _, err := os.Stat(cassettePath + ".lock"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the lock file to be removed, got %v", err)
+	}
+
+	replayer, err := truora.NewClient(
+		truora.WithAPIKey("replay-key"),
+		truora.WithEndpoints(truora.Endpoints{Identity: "http://replay.invalid"}),
+		truora.WithCassette(cassettePath, truora.CassetteModeReplay),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := map[string]bool{}
+	for range flowIDs {
+		flow, err := replayer.CreateFlow(context.Background(), &truora.IdentityProcessFlow{
+			Name: "cassette",
+			Type: "permanent",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		replayed[flow.FlowID] = true
+	}
+
+	for _, flowID := range flowIDs {
+		if !replayed[flowID] {
+			t.Errorf("flow %s wasn't recorded", flowID)
+		}
+	}
+}
+
+func TestCassetteRecordRedactsPII(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+  "process_id": "IDP1",
+  "status": "success",
+  "phone": 573001234567,
+  "address": {"street": "Calle 1 # 2-3", "city": "Bogota"},
+  "validations": [{"validation_id": "VLD1", "national_id": 1020304050, "date_of_birth": ["1990", "01", "02"]}]
+}`))
+	}))
+	defer server.Close()
+
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := truora.NewClient(
+		truora.WithAPIKey("cassette-key"),
+		truora.WithEndpoints(truora.Endpoints{Identity: server.URL}),
+		truora.WithCassette(cassettePath, truora.CassetteModeRecord),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := recorder.GetProcessResult(context.Background(), "IDP1"); err != nil {
+		t.Fatal(err)
+	}
+
+	cassette, err := os.ReadFile(cassettePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"cassette-key", "573001234567", "Calle 1", "Bogota", "1020304050", "1990"} {
+		if strings.Contains(string(cassette), secret) {
+			t.Fatalf("expected %q to be masked in the cassette:\n%s", secret, cassette)
+		}
+	}
+
+	server.Close()
+
+	replayer, err := truora.NewClient(
+		truora.WithAPIKey("replay-key"),
+		truora.WithEndpoints(truora.Endpoints{Identity: "http://replay.invalid"}),
+		truora.WithCassette(cassettePath, truora.CassetteModeReplay),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, err := replayer.GetProcessResult(context.Background(), "IDP1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if replayed.ProcessID != "IDP1" || len(replayed.Validations) != 1 || replayed.Validations[0].ValidationID != "VLD1" {
+		t.Fatalf("expected the other fields to be replayed, got %+v", replayed)
+	}
+}
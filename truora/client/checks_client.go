@@ -0,0 +1,149 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DatasetWeight is how much a dataset weighs in the score of a custom type
+type DatasetWeight struct {
+	Dataset string  `json:"dataset"`
+	Weight  float64 `json:"weight"`
+}
+
+// CheckCustomType is a background check score configuration for a country
+type CheckCustomType struct {
+	Country        string           `json:"country"`
+	Type           string           `json:"type"`
+	DatasetWeights []*DatasetWeight `json:"dataset_weights"`
+}
+
+func (t *CheckCustomType) formValues() url.Values {
+	values := url.Values{}
+	values.Set("country", t.Country)
+	values.Set("type", t.Type)
+
+	for i, datasetWeight := range t.DatasetWeights {
+		values.Set(fmt.Sprintf("dataset_weights[%d][dataset]", i), datasetWeight.Dataset)
+		values.Set(fmt.Sprintf("dataset_weights[%d][weight]", i), strconv.FormatFloat(datasetWeight.Weight, 'f', -1, 64))
+	}
+
+	return values
+}
+
+func customTypeQuery(country, customType string) string {
+	values := url.Values{}
+	values.Set("country", country)
+	values.Set("type", customType)
+
+	return values.Encode()
+}
+
+func (c *TruoraClient) GetCheckCustomType(ctx context.Context, country, customType string) (*CheckCustomType, error) {
+	client := c.HTTPClient
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/v1/config?%s", c.Endpoints.Checks, customTypeQuery(country, customType)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error getting check custom type: %s\n%s", resp.Status, stringBody)
+	}
+
+	var checkCustomType CheckCustomType
+	if err := json.NewDecoder(resp.Body).Decode(&checkCustomType); err != nil {
+		return nil, err
+	}
+
+	return &checkCustomType, nil
+}
+
+func (c *TruoraClient) CreateCheckCustomType(ctx context.Context, checkCustomType *CheckCustomType) (*CheckCustomType, error) {
+	return c.saveCheckCustomType(ctx, "POST", checkCustomType)
+}
+
+func (c *TruoraClient) UpdateCheckCustomType(ctx context.Context, checkCustomType *CheckCustomType) (*CheckCustomType, error) {
+	return c.saveCheckCustomType(ctx, "PUT", checkCustomType)
+}
+
+func (c *TruoraClient) saveCheckCustomType(ctx context.Context, method string, checkCustomType *CheckCustomType) (*CheckCustomType, error) {
+	client := c.HTTPClient
+
+	reader := strings.NewReader(checkCustomType.formValues().Encode())
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/v1/config", c.Endpoints.Checks), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error saving check custom type: %s\n%s", resp.Status, stringBody)
+	}
+
+	var checkCustomTypeResponse CheckCustomType
+	if err := json.NewDecoder(resp.Body).Decode(&checkCustomTypeResponse); err != nil {
+		return nil, err
+	}
+
+	return &checkCustomTypeResponse, nil
+}
+
+func (c *TruoraClient) DeleteCheckCustomType(ctx context.Context, country, customType string) error {
+	client := c.HTTPClient
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/v1/config?%s", c.Endpoints.Checks, customTypeQuery(country, customType)), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return fmt.Errorf("error deleting check custom type: %s\n%s", resp.Status, stringBody)
+	}
+
+	return nil
+}
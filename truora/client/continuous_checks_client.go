@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ContinuousCheckCanceledStatus is the status of a continuous check that
+// won't be run again
+const ContinuousCheckCanceledStatus = "canceled"
+
+// ContinuousCheck re-runs a background check periodically and notifies changes
+type ContinuousCheck struct {
+	ContinuousCheckID string     `json:"continuous_check_id,omitempty"`
+	NationalID        string     `json:"national_id"`
+	Country           string     `json:"country"`
+	Type              string     `json:"type"`
+	Frequency         string     `json:"frequency"`
+	Status            string     `json:"status,omitempty"`
+	CreationDate      *time.Time `json:"creation_date,omitempty"`
+	NextRunDate       *time.Time `json:"next_run_date,omitempty"`
+}
+
+func (c *TruoraClient) GetContinuousCheck(ctx context.Context, continuousCheckID string) (*ContinuousCheck, error) {
+	client := c.HTTPClient
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/v1/continuous-checks/%s", c.Endpoints.Checks, continuousCheckID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error getting continuous check: %s\n%s", resp.Status, stringBody)
+	}
+
+	var continuousCheck ContinuousCheck
+	if err := json.NewDecoder(resp.Body).Decode(&continuousCheck); err != nil {
+		return nil, err
+	}
+
+	return &continuousCheck, nil
+}
+
+func (c *TruoraClient) CreateContinuousCheck(ctx context.Context, continuousCheck *ContinuousCheck) (*ContinuousCheck, error) {
+	client := c.HTTPClient
+
+	values := url.Values{}
+	values.Set("national_id", continuousCheck.NationalID)
+	values.Set("country", continuousCheck.Country)
+	values.Set("type", continuousCheck.Type)
+	values.Set("frequency", continuousCheck.Frequency)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/v1/continuous-checks", c.Endpoints.Checks), strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error creating continuous check: %s\n%s", resp.Status, stringBody)
+	}
+
+	var continuousCheckResponse ContinuousCheck
+	if err := json.NewDecoder(resp.Body).Decode(&continuousCheckResponse); err != nil {
+		return nil, err
+	}
+
+	return &continuousCheckResponse, nil
+}
+
+// UpdateContinuousCheckFrequency changes how often the check is run, it is
+// the only setting that can be changed after creation
+func (c *TruoraClient) UpdateContinuousCheckFrequency(ctx context.Context, continuousCheckID, frequency string) (*ContinuousCheck, error) {
+	client := c.HTTPClient
+
+	values := url.Values{}
+	values.Set("frequency", frequency)
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/v1/continuous-checks/%s", c.Endpoints.Checks, continuousCheckID), strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error updating continuous check: %s\n%s", resp.Status, stringBody)
+	}
+
+	var continuousCheckResponse ContinuousCheck
+	if err := json.NewDecoder(resp.Body).Decode(&continuousCheckResponse); err != nil {
+		return nil, err
+	}
+
+	return &continuousCheckResponse, nil
+}
+
+func (c *TruoraClient) CancelContinuousCheck(ctx context.Context, continuousCheckID string) error {
+	client := c.HTTPClient
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/v1/continuous-checks/%s", c.Endpoints.Checks, continuousCheckID), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return fmt.Errorf("error canceling continuous check: %s\n%s", resp.Status, stringBody)
+	}
+
+	return nil
+}
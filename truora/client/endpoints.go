@@ -0,0 +1,87 @@
+package client
+
+import "os"
+
+const (
+	IdentityAPIServerEnvironmentVariableName    = "TRUORA_API_SERVER"
+	ChecksAPIServerEnvironmentVariableName      = "TRUORA_CHECKS_API_SERVER"
+	AccountAPIServerEnvironmentVariableName     = "TRUORA_ACCOUNT_API_SERVER"
+	ValidationsAPIServerEnvironmentVariableName = "TRUORA_VALIDATIONS_API_SERVER"
+	ConnectAPIServerEnvironmentVariableName     = "TRUORA_CONNECT_API_SERVER"
+	WebServerEnvironmentVariableName            = "TRUORA_WEB_SERVER"
+
+	DefaultIdentityAPIServer    = "https://api.identity.truora.com"
+	DefaultChecksAPIServer      = "https://api.checks.truora.com"
+	DefaultAccountAPIServer     = "https://api.account.truora.com"
+	DefaultValidationsAPIServer = "https://api.validations.truora.com"
+	DefaultConnectAPIServer     = "https://api.connect.truora.com"
+	DefaultWebServer            = "https://identity.truora.com"
+
+	// Deprecated: use IdentityAPIServerEnvironmentVariableName instead.
+	APIServerEnvironmentVariableName = IdentityAPIServerEnvironmentVariableName
+	// Deprecated: use DefaultIdentityAPIServer instead.
+	DefaultAPIServer = DefaultIdentityAPIServer
+)
+
+// Endpoints holds the API server of every Truora product, and the web
+// application where Digital Identity processes are opened
+type Endpoints struct {
+	Identity    string
+	Checks      string
+	Account     string
+	Validations string
+	Connect     string
+	Web         string
+}
+
+// DefaultEndpoints returns the production API servers
+func DefaultEndpoints() Endpoints {
+	return Endpoints{
+		Identity:    DefaultIdentityAPIServer,
+		Checks:      DefaultChecksAPIServer,
+		Account:     DefaultAccountAPIServer,
+		Validations: DefaultValidationsAPIServer,
+		Connect:     DefaultConnectAPIServer,
+		Web:         DefaultWebServer,
+	}
+}
+
+func endpointsFromEnvironment() Endpoints {
+	return Endpoints{
+		Identity:    os.Getenv(IdentityAPIServerEnvironmentVariableName),
+		Checks:      os.Getenv(ChecksAPIServerEnvironmentVariableName),
+		Account:     os.Getenv(AccountAPIServerEnvironmentVariableName),
+		Validations: os.Getenv(ValidationsAPIServerEnvironmentVariableName),
+		Connect:     os.Getenv(ConnectAPIServerEnvironmentVariableName),
+		Web:         os.Getenv(WebServerEnvironmentVariableName),
+	}
+}
+
+// merge returns e with the non empty servers of overrides applied
+func (e Endpoints) merge(overrides Endpoints) Endpoints {
+	if overrides.Identity != "" {
+		e.Identity = overrides.Identity
+	}
+
+	if overrides.Checks != "" {
+		e.Checks = overrides.Checks
+	}
+
+	if overrides.Account != "" {
+		e.Account = overrides.Account
+	}
+
+	if overrides.Validations != "" {
+		e.Validations = overrides.Validations
+	}
+
+	if overrides.Connect != "" {
+		e.Connect = overrides.Connect
+	}
+
+	if overrides.Web != "" {
+		e.Web = overrides.Web
+	}
+
+	return e
+}
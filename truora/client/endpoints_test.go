@@ -0,0 +1,87 @@
+package client_test
+
+import (
+	"testing"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+func TestProcessURL(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      string
+		opts     []truora.TruoraClientOption
+		expected string
+	}{
+		{
+			name:     "default web server",
+			expected: "https://identity.truora.com/?token=a+b",
+		},
+		{
+			name:     "environment",
+			env:      "https://identity.example.com",
+			expected: "https://identity.example.com/?token=a+b",
+		},
+		{
+			name:     "option overrides environment",
+			env:      "https://identity.example.com",
+			opts:     []truora.TruoraClientOption{truora.WithEndpoints(truora.Endpoints{Web: "http://localhost:8080"})},
+			expected: "http://localhost:8080/?token=a+b",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(truora.WebServerEnvironmentVariableName, tc.env)
+
+			client, err := truora.NewClient(append([]truora.TruoraClientOption{truora.WithAPIKey("key")}, tc.opts...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if actual := client.ProcessURL("a b"); actual != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidationsEndpoint(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      string
+		opts     []truora.TruoraClientOption
+		expected string
+	}{
+		{
+			name:     "default validations server",
+			expected: truora.DefaultValidationsAPIServer,
+		},
+		{
+			name:     "environment",
+			env:      "https://validations.example.com",
+			expected: "https://validations.example.com",
+		},
+		{
+			name:     "option overrides environment",
+			env:      "https://validations.example.com",
+			opts:     []truora.TruoraClientOption{truora.WithEndpoints(truora.Endpoints{Validations: "http://localhost:8080"})},
+			expected: "http://localhost:8080",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(truora.ValidationsAPIServerEnvironmentVariableName, tc.env)
+
+			client, err := truora.NewClient(append([]truora.TruoraClientOption{truora.WithAPIKey("key")}, tc.opts...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if client.Endpoints.Validations != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, client.Endpoints.Validations)
+			}
+		})
+	}
+}
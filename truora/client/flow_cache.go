@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// WithFlowCache caches the flows returned by GetFlow for the lifetime of the
+// client. Concurrent gets of the same flow share a single request, and flows
+// are dropped from the cache when they are updated, deleted, adopted or
+// listed through the client.
+func WithFlowCache() TruoraClientOption {
+	return func(client *TruoraClient) {
+		client.flowCache = newFlowCache()
+	}
+}
+
+type flowCache struct {
+	group singleflight.Group
+
+	mu    sync.Mutex
+	flows map[string]*IdentityProcessFlowResponse
+	// generations changes on every invalidation, so fetches that started
+	// before a mutation don't store what they read
+	generations map[string]uint64
+}
+
+func newFlowCache() *flowCache {
+	return &flowCache{
+		flows:       map[string]*IdentityProcessFlowResponse{},
+		generations: map[string]uint64{},
+	}
+}
+
+// get returns the cached flow or calls fetch once for all the concurrent
+// callers asking for flowID. The shared fetch isn't canceled when a single
+// caller gives up.
+func (c *flowCache) get(ctx context.Context, flowID string, fetch func(context.Context) (*IdentityProcessFlowResponse, error)) (*IdentityProcessFlowResponse, error) {
+	c.mu.Lock()
+	flow, ok := c.flows[flowID]
+	generation := c.generations[flowID]
+	c.mu.Unlock()
+
+	if ok {
+		return CopyFlowResponse(flow)
+	}
+
+	result := c.group.DoChan(flowID, func() (interface{}, error) {
+		flow, err := fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		if c.generations[flowID] == generation {
+			c.flows[flowID] = flow
+		}
+		c.mu.Unlock()
+
+		return flow, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-result:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+
+		return CopyFlowResponse(r.Val.(*IdentityProcessFlowResponse))
+	}
+}
+
+// invalidate drops flowID from the cache and detaches in flight fetches of it
+func (c *flowCache) invalidate(flowID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.flows, flowID)
+	c.generations[flowID]++
+	c.group.Forget(flowID)
+}
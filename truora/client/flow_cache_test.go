@@ -0,0 +1,153 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestFlowCacheCoalescesAndInvalidates(t *testing.T) {
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	flow := server.AddFlow(&truora.IdentityProcessFlow{Name: "cached", Type: "permanent"})
+
+	server.InjectFault(truoratest.Fault{
+		Method:  http.MethodGet,
+		Latency: 50 * time.Millisecond,
+	})
+
+	var gets int64
+
+	countGets := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodGet {
+				atomic.AddInt64(&gets, 1)
+			}
+
+			return next.RoundTrip(req)
+		})
+	}
+
+	client, err := server.NewClient(truora.WithFlowCache(), truora.WithMiddleware(countGets))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := client.GetFlow(ctx, flow.FlowID); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if _, err := client.GetFlow(ctx, flow.FlowID); err != nil {
+		t.Fatal(err)
+	}
+
+	if gets != 1 {
+		t.Fatalf("expected a single request, got %d", gets)
+	}
+
+	if _, err := client.UpdateFlow(ctx, flow.FlowID, &truora.IdentityProcessFlow{Name: "renamed", Type: "permanent"}); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := client.GetFlow(ctx, flow.FlowID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.Name != "renamed" || gets != 2 {
+		t.Fatalf("expected the update to invalidate the cache, got %q after %d requests", updated.Name, gets)
+	}
+}
+
+func TestFlowCacheDropsListedFlows(t *testing.T) {
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	flow := server.AddFlow(&truora.IdentityProcessFlow{Name: "cached", Type: "permanent"})
+
+	cached, err := server.NewClient(truora.WithFlowCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// another process of the run changes the flow behind the cache
+	other, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	if _, err := cached.GetFlow(ctx, flow.FlowID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := other.UpdateFlow(ctx, flow.FlowID, &truora.IdentityProcessFlow{Name: "renamed", Type: "permanent"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cached.ListFlows(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := cached.GetFlow(ctx, flow.FlowID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.Name != "renamed" {
+		t.Fatalf("expected listing to drop the cached flow, got %q", updated.Name)
+	}
+}
+
+func TestCopyFlowResponse(t *testing.T) {
+	flow := &truora.IdentityProcessFlowResponse{
+		FlowID: "IPF1",
+		IdentityVerifications: []*truora.IdentityVerification{
+			{Name: "document-validation", Config: map[string]interface{}{"country": "CO"}},
+		},
+	}
+
+	flowCopy, err := truora.CopyFlowResponse(flow)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flowCopy.IdentityVerifications[0].Config["country"] = "MX"
+
+	if flow.IdentityVerifications[0].Config["country"] != "CO" {
+		t.Fatal("expected the copy not to share the verification config")
+	}
+
+	flow.IdentityVerifications[0].Config["invalid"] = func() {}
+
+	if _, err := truora.CopyFlowResponse(flow); err == nil {
+		t.Fatal("expected an error copying a flow that can't be encoded")
+	}
+}
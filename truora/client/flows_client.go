@@ -4,19 +4,28 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
 )
 
 const (
-	APIKeyEnvironmentVariableName    = "TRUORA_API_KEY"
-	APIServerEnvironmentVariableName = "TRUORA_API_SERVER"
+	APIKeyEnvironmentVariableName = "TRUORA_API_KEY"
 )
 
 var (
-	ErrAPIKeyNotProvided    = fmt.Errorf("API key not provided")
+	ErrAPIKeyNotProvided = fmt.Errorf("API key not provided")
+	ErrNotFound          = fmt.Errorf("resource not found")
+
+	// Deprecated: NewClient no longer returns it, every endpoint has a
+	// default API server.
 	ErrAPIServerNotProvided = fmt.Errorf("API server not provided")
 )
 
@@ -138,12 +147,35 @@ type IdentityProcessFlowResponse struct {
 	IdentityVerifications []*IdentityVerification `json:"identity_verifications"`
 }
 
+// CopyFlowResponse deep copies a flow through its JSON encoding, so the copy
+// shares no config or verification with flow
+func CopyFlowResponse(flow *IdentityProcessFlowResponse) (*IdentityProcessFlowResponse, error) {
+	flowMarshal, err := json.Marshal(flow)
+	if err != nil {
+		return nil, fmt.Errorf("error copying flow %s: %w", flow.FlowID, err)
+	}
+
+	var flowCopy IdentityProcessFlowResponse
+	if err := json.Unmarshal(flowMarshal, &flowCopy); err != nil {
+		return nil, fmt.Errorf("error copying flow %s: %w", flow.FlowID, err)
+	}
+
+	return &flowCopy, nil
+}
+
 type TruoraClientOption func(*TruoraClient)
 
 type TruoraClient struct {
 	APIKey     string
-	APIServer  string
+	Endpoints  Endpoints
 	HTTPClient *http.Client
+	UserAgent  string
+
+	middlewares []Middleware
+	limiter     *rateLimiter
+	flowCache   *flowCache
+	// createdFlows holds the IDs of the flows created through the client
+	createdFlows sync.Map
 }
 
 func WithAPIKey(apiKey string) TruoraClientOption {
@@ -153,38 +185,71 @@ func WithAPIKey(apiKey string) TruoraClientOption {
 
 }
 
+// WithAPIServer sets the identity API server, which hosts the flows API
 func WithAPIServer(apiServer string) TruoraClientOption {
 	return func(client *TruoraClient) {
-		client.APIServer = apiServer
+		client.Endpoints.Identity = apiServer
+	}
+}
+
+// WithEndpoints overrides the API servers that are set in endpoints, empty
+// ones keep their current value
+func WithEndpoints(endpoints Endpoints) TruoraClientOption {
+	return func(client *TruoraClient) {
+		client.Endpoints = client.Endpoints.merge(endpoints)
 	}
 }
 
 func NewClient(opts ...TruoraClientOption) (*TruoraClient, error) {
 	client := &TruoraClient{
 		APIKey:     os.Getenv(APIKeyEnvironmentVariableName),
-		APIServer:  os.Getenv(APIServerEnvironmentVariableName),
+		Endpoints:  DefaultEndpoints().merge(endpointsFromEnvironment()),
 		HTTPClient: &http.Client{},
+		UserAgent:  DefaultUserAgent,
+		limiter:    newRateLimiter(rate.Inf, 1),
 	}
 
 	for _, o := range opts {
 		o(client)
 	}
 
+	if path := os.Getenv(CassetteEnvironmentVariableName); path != "" {
+		mode := CassetteMode(os.Getenv(CassetteModeEnvironmentVariableName))
+		if mode == "" {
+			mode = CassetteModeReplay
+		}
+
+		if mode != CassetteModeRecord && mode != CassetteModeReplay {
+			return nil, fmt.Errorf("unknown cassette mode %q, expected %s or %s", mode, CassetteModeRecord, CassetteModeReplay)
+		}
+
+		WithCassette(path, mode)(client)
+	}
+
+	client.buildTransport(os.Getenv(LogBodiesEnvironmentVariableName) != "")
+
 	if client.APIKey == "" {
 		return nil, ErrAPIKeyNotProvided
 	}
 
-	if client.APIServer == "" {
-		return nil, ErrAPIServerNotProvided
+	return client, nil
+}
+
+// GetFlow returns a flow, from the cache when WithFlowCache is used
+func (c *TruoraClient) GetFlow(ctx context.Context, flowID string) (*IdentityProcessFlowResponse, error) {
+	if c.flowCache == nil {
+		return c.getFlow(ctx, flowID)
 	}
 
-	return client, nil
+	return c.flowCache.get(ctx, flowID, func(ctx context.Context) (*IdentityProcessFlowResponse, error) {
+		return c.getFlow(ctx, flowID)
+	})
 }
 
-func (c *TruoraClient) GetFlow(flowID string) (*IdentityProcessFlowResponse, error) {
+func (c *TruoraClient) getFlow(ctx context.Context, flowID string) (*IdentityProcessFlowResponse, error) {
 	client := c.HTTPClient
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/flows/%s", c.APIServer, flowID), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/v1/flows/%s", c.Endpoints.Identity, flowID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -198,6 +263,16 @@ func (c *TruoraClient) GetFlow(flowID string) (*IdentityProcessFlowResponse, err
 
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error getting flow: %s\n%s", resp.Status, stringBody)
+	}
+
 	var flow IdentityProcessFlowResponse
 	if err := json.NewDecoder(resp.Body).Decode(&flow); err != nil {
 		return nil, err
@@ -206,7 +281,94 @@ func (c *TruoraClient) GetFlow(flowID string) (*IdentityProcessFlowResponse, err
 	return &flow, nil
 }
 
+// ListFlowsResponse is the body returned when listing flows
+type ListFlowsResponse struct {
+	Flows []*IdentityProcessFlowResponse `json:"flows"`
+}
+
+func (c *TruoraClient) ListFlows(ctx context.Context) ([]*IdentityProcessFlowResponse, error) {
+	client := c.HTTPClient
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/v1/flows", c.Endpoints.Identity), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error listing flows: %s\n%s", resp.Status, stringBody)
+	}
+
+	var listResponse ListFlowsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&listResponse); err != nil {
+		return nil, err
+	}
+
+	// listed flows may have changed outside the client, and may be summaries,
+	// so they are dropped from the cache instead of stored
+	for _, flow := range listResponse.Flows {
+		c.invalidateFlow(flow.FlowID)
+	}
+
+	return listResponse.Flows, nil
+}
+
+// CreateFlow creates a flow. Requests carry an idempotency key shared by the
+// retries of the call, so the API can recognize a resent request. When a
+// request fails without telling whether the flow was created, a matching flow
+// created since the first attempt is adopted, and the request is only sent
+// again when there is none, so lost responses don't leave duplicated flows
+// behind.
 func (c *TruoraClient) CreateFlow(ctx context.Context, flow *IdentityProcessFlow) (*IdentityProcessFlowResponse, error) {
+	start := time.Now()
+	idempotencyKey := newIdempotencyKey()
+
+	for attempt := 1; ; attempt++ {
+		flowResponse, err := c.createFlow(ctx, flow, idempotencyKey)
+
+		var ambiguousErr *ambiguousCreateError
+		if !errors.As(err, &ambiguousErr) {
+			if err == nil {
+				c.createdFlows.Store(flowResponse.FlowID, true)
+			}
+
+			return flowResponse, err
+		}
+
+		adopted, adoptErr := c.adoptCreatedFlow(ctx, flow, start)
+		if adoptErr != nil {
+			return nil, errors.Join(err, adoptErr)
+		}
+
+		if adopted != nil {
+			c.createdFlows.Store(adopted.FlowID, true)
+			c.invalidateFlow(adopted.FlowID)
+
+			return adopted, nil
+		}
+
+		if attempt == createFlowAttempts || ctx.Err() != nil {
+			return nil, err
+		}
+
+		tflog.Warn(ctx, "Creating flow again after a request that failed", map[string]interface{}{
+			"attempt": attempt + 1,
+			"error":   err.Error(),
+		})
+	}
+}
+
+func (c *TruoraClient) createFlow(ctx context.Context, flow *IdentityProcessFlow, idempotencyKey string) (*IdentityProcessFlowResponse, error) {
 	client := c.HTTPClient
 
 	marshalledFlow, err := json.Marshal(flow)
@@ -216,30 +378,43 @@ func (c *TruoraClient) CreateFlow(ctx context.Context, flow *IdentityProcessFlow
 
 	reader := bytes.NewBuffer(marshalledFlow)
 
-	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/v1/flows", c.APIServer), reader)
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/v1/flows", c.Endpoints.Identity), reader)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Truora-Api-Key", c.APIKey)
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
 
-	resp, err := client.Do(req)
+	var wroteRequest atomic.Bool
+
+	resp, err := client.Do(withWroteRequest(req, &wroteRequest))
 	if err != nil {
+		if wroteRequest.Load() {
+			return nil, &ambiguousCreateError{err: err}
+		}
+
 		return nil, err
 	}
 
+	defer resp.Body.Close()
+
 	if resp.StatusCode != http.StatusCreated {
 		stringBody := new(bytes.Buffer)
 		stringBody.ReadFrom(resp.Body)
-		return nil, fmt.Errorf("error creating flow: %s\n%s", resp.Status, stringBody)
-	}
+		err := fmt.Errorf("error creating flow: %s\n%s", resp.Status, stringBody)
 
-	defer resp.Body.Close()
+		if isAmbiguousStatus(resp.StatusCode) {
+			return nil, &ambiguousCreateError{err: err}
+		}
+
+		return nil, err
+	}
 
 	var flowResponse IdentityProcessFlowResponse
 	if err := json.NewDecoder(resp.Body).Decode(&flowResponse); err != nil {
-		return nil, err
+		return nil, &ambiguousCreateError{err: err}
 	}
 
 	return &flowResponse, nil
@@ -248,12 +423,15 @@ func (c *TruoraClient) CreateFlow(ctx context.Context, flow *IdentityProcessFlow
 func (c *TruoraClient) UpdateFlow(ctx context.Context, flowID string, flow *IdentityProcessFlow) (*IdentityProcessFlowResponse, error) {
 	client := c.HTTPClient
 
+	// invalidated even when the update fails, it may have been applied
+	defer c.invalidateFlow(flowID)
+
 	marshalledFlow, err := json.Marshal(flow)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v1/flows/%s", c.APIServer, flowID), bytes.NewBuffer(marshalledFlow))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/v1/flows/%s", c.Endpoints.Identity, flowID), bytes.NewBuffer(marshalledFlow))
 	if err != nil {
 		return nil, err
 	}
@@ -281,10 +459,12 @@ func (c *TruoraClient) UpdateFlow(ctx context.Context, flowID string, flow *Iden
 	return &flowResponse, nil
 }
 
-func (c *TruoraClient) DeleteFlow(flowID string) error {
+func (c *TruoraClient) DeleteFlow(ctx context.Context, flowID string) error {
 	client := c.HTTPClient
 
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/v1/flows/%s", c.APIServer, flowID), nil)
+	defer c.invalidateFlow(flowID)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/v1/flows/%s", c.Endpoints.Identity, flowID), nil)
 	if err != nil {
 		return err
 	}
@@ -296,7 +476,7 @@ func (c *TruoraClient) DeleteFlow(flowID string) error {
 		return err
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
 		stringBody := new(bytes.Buffer)
 		stringBody.ReadFrom(resp.Body)
 		return fmt.Errorf("error deleting flow: %s\n%s", resp.Status, stringBody)
@@ -306,3 +486,9 @@ func (c *TruoraClient) DeleteFlow(flowID string) error {
 
 	return nil
 }
+
+func (c *TruoraClient) invalidateFlow(flowID string) {
+	if c.flowCache != nil {
+		c.flowCache.invalidate(flowID)
+	}
+}
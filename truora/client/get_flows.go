@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultGetFlowsConcurrency is how many flows GetFlows fetches at once when
+// no concurrency is set
+const DefaultGetFlowsConcurrency = 8
+
+// GetFlowsOptions configures GetFlows
+type GetFlowsOptions struct {
+	// Concurrency limits how many flows are fetched at once, the client rate
+	// limit still applies on top of it
+	Concurrency int
+}
+
+// GetFlowResult is the outcome of fetching a single flow, either Flow or Err
+// is set
+type GetFlowResult struct {
+	FlowID string
+	Flow   *IdentityProcessFlowResponse
+	Err    error
+}
+
+// GetFlows fetches the flows in parallel. It returns a result per ID in the
+// same order as ids, even when some of them fail, and an error joining every
+// failure. Missing flows fail with ErrNotFound.
+func (c *TruoraClient) GetFlows(ctx context.Context, ids []string, opts *GetFlowsOptions) ([]*GetFlowResult, error) {
+	concurrency := DefaultGetFlowsConcurrency
+	if opts != nil && opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+
+	results := make([]*GetFlowResult, len(ids))
+	semaphore := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+
+	for i, flowID := range ids {
+		results[i] = &GetFlowResult{FlowID: flowID}
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(result *GetFlowResult) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			result.Flow, result.Err = c.GetFlow(ctx, result.FlowID)
+		}(results[i])
+	}
+
+	wg.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("flow %s: %w", result.FlowID, result.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
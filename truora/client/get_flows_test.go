@@ -0,0 +1,84 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+func TestGetFlowsReturnsPartialResults(t *testing.T) {
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	var ids []string
+	for _, name := range []string{"first", "second", "third", "fourth"} {
+		ids = append(ids, server.AddFlow(&truora.IdentityProcessFlow{Name: name, Type: "permanent"}).FlowID)
+	}
+
+	ids = append(ids[:2], append([]string{"IPFmissing"}, ids[2:]...)...)
+
+	server.InjectFault(truoratest.Fault{
+		Method:  http.MethodGet,
+		Latency: 20 * time.Millisecond,
+	})
+
+	var inFlight, maxInFlight int64
+
+	trackConcurrency := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			current := atomic.AddInt64(&inFlight, 1)
+			defer atomic.AddInt64(&inFlight, -1)
+
+			for {
+				previous := atomic.LoadInt64(&maxInFlight)
+				if current <= previous || atomic.CompareAndSwapInt64(&maxInFlight, previous, current) {
+					break
+				}
+			}
+
+			return next.RoundTrip(req)
+		})
+	}
+
+	client, err := server.NewClient(truora.WithMiddleware(trackConcurrency))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := client.GetFlows(context.Background(), ids, &truora.GetFlowsOptions{Concurrency: 2})
+	if !errors.Is(err, truora.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if len(results) != len(ids) {
+		t.Fatalf("expected %d results, got %d", len(ids), len(results))
+	}
+
+	for i, result := range results {
+		if result.FlowID != ids[i] {
+			t.Fatalf("expected result %d to be %s, got %s", i, ids[i], result.FlowID)
+		}
+
+		if result.FlowID == "IPFmissing" {
+			if !errors.Is(result.Err, truora.ErrNotFound) {
+				t.Fatalf("expected ErrNotFound for %s, got %v", result.FlowID, result.Err)
+			}
+
+			continue
+		}
+
+		if result.Err != nil || result.Flow.FlowID != result.FlowID {
+			t.Fatalf("unexpected result for %s: %v", result.FlowID, result.Err)
+		}
+	}
+
+	if maxInFlight > 2 {
+		t.Fatalf("expected at most 2 requests at once, got %d", maxInFlight)
+	}
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Hook is a webhook registered in the Truora account API
+type Hook struct {
+	HookID      string `json:"hook_id,omitempty"`
+	EventType   string `json:"event_type"`
+	EventAction string `json:"event_action"`
+	URL         string `json:"url"`
+	AuthType    string `json:"auth_type,omitempty"`
+	Username    string `json:"username,omitempty"`
+	Password    string `json:"password,omitempty"`
+	Token       string `json:"auth_token,omitempty"`
+	Status      string `json:"status,omitempty"`
+}
+
+func (h *Hook) formValues() url.Values {
+	values := url.Values{}
+	values.Set("event_type", h.EventType)
+	values.Set("event_action", h.EventAction)
+	values.Set("url", h.URL)
+
+	if h.AuthType != "" {
+		values.Set("auth_type", h.AuthType)
+	}
+
+	if h.Username != "" {
+		values.Set("username", h.Username)
+	}
+
+	if h.Password != "" {
+		values.Set("password", h.Password)
+	}
+
+	if h.Token != "" {
+		values.Set("auth_token", h.Token)
+	}
+
+	if h.Status != "" {
+		values.Set("status", h.Status)
+	}
+
+	return values
+}
+
+func (c *TruoraClient) GetHook(ctx context.Context, hookID string) (*Hook, error) {
+	client := c.HTTPClient
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/v1/hooks/%s", c.Endpoints.Account, hookID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error getting hook: %s\n%s", resp.Status, stringBody)
+	}
+
+	var hook Hook
+	if err := json.NewDecoder(resp.Body).Decode(&hook); err != nil {
+		return nil, err
+	}
+
+	return &hook, nil
+}
+
+func (c *TruoraClient) CreateHook(ctx context.Context, hook *Hook) (*Hook, error) {
+	client := c.HTTPClient
+
+	reader := strings.NewReader(hook.formValues().Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/v1/hooks", c.Endpoints.Account), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error creating hook: %s\n%s", resp.Status, stringBody)
+	}
+
+	var hookResponse Hook
+	if err := json.NewDecoder(resp.Body).Decode(&hookResponse); err != nil {
+		return nil, err
+	}
+
+	return &hookResponse, nil
+}
+
+func (c *TruoraClient) UpdateHook(ctx context.Context, hookID string, hook *Hook) (*Hook, error) {
+	client := c.HTTPClient
+
+	reader := strings.NewReader(hook.formValues().Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/v1/hooks/%s", c.Endpoints.Account, hookID), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error updating hook: %s\n%s", resp.Status, stringBody)
+	}
+
+	var hookResponse Hook
+	if err := json.NewDecoder(resp.Body).Decode(&hookResponse); err != nil {
+		return nil, err
+	}
+
+	return &hookResponse, nil
+}
+
+func (c *TruoraClient) DeleteHook(ctx context.Context, hookID string) error {
+	client := c.HTTPClient
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/v1/hooks/%s", c.Endpoints.Account, hookID), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return fmt.Errorf("error deleting hook: %s\n%s", resp.Status, stringBody)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// createFlowAttempts bounds how many times a create is sent when it fails
+	// without telling whether the flow was created
+	createFlowAttempts = 3
+
+	// adoptionClockSkew is how far behind the local clock the API clock can be
+	// and a flow still be adopted, its creation date comes from the API
+	adoptionClockSkew = time.Minute
+
+	// adoptionTimeout bounds the lookup of a flow after an ambiguous failure,
+	// which runs even if the context of the create was canceled
+	adoptionTimeout = 30 * time.Second
+)
+
+// ambiguousCreateError is returned when a create request failed in a way
+// that doesn't tell whether the flow was created
+type ambiguousCreateError struct {
+	err error
+}
+
+func (e *ambiguousCreateError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ambiguousCreateError) Unwrap() error {
+	return e.err
+}
+
+// isAmbiguousStatus reports statuses returned when the request may have been
+// processed even though the response says otherwise
+func isAmbiguousStatus(statusCode int) bool {
+	return statusCode == http.StatusRequestTimeout || statusCode >= http.StatusInternalServerError
+}
+
+// withWroteRequest traces req to record in wrote whether it was fully
+// written. Errors before that, like DNS failures and refused connections,
+// can't have created anything, while errors after it, like timeouts and
+// connection resets, may have.
+func withWroteRequest(req *http.Request, wrote *atomic.Bool) *http.Request {
+	trace := &httptrace.ClientTrace{
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			if info.Err == nil {
+				wrote.Store(true)
+			}
+		},
+	}
+
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
+
+// newIdempotencyKey returns a random key, generated once per CreateFlow call
+// and reused by its retries only, so separate creates of the same content
+// aren't taken for each other
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// flowContentHash hashes the fields of a flow that are set on create. IDs
+// assigned by the API are left out so a request can be compared with the
+// flow it created.
+func flowContentHash(name, flowType string, config *IdentityFlowConfig, verifications []*IdentityVerification) string {
+	content := struct {
+		Name          string                  `json:"name"`
+		Type          string                  `json:"type"`
+		Config        *IdentityFlowConfig     `json:"config"`
+		Verifications []*IdentityVerification `json:"identity_verifications"`
+	}{
+		Name:   name,
+		Type:   flowType,
+		Config: config,
+	}
+
+	for _, verification := range verifications {
+		verificationCopy := *verification
+		verificationCopy.VerificationID = ""
+		verificationCopy.Steps = nil
+
+		for _, step := range verification.Steps {
+			stepCopy := *step
+			stepCopy.StepID = ""
+			verificationCopy.Steps = append(verificationCopy.Steps, &stepCopy)
+		}
+
+		content.Verifications = append(content.Verifications, &verificationCopy)
+	}
+
+	contentMarshal, _ := json.Marshal(content)
+	sum := sha256.Sum256(contentMarshal)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// adoptCreatedFlow looks for the flow an ambiguous create may have created:
+// a single flow with the same name and content created after since, minus
+// the clock skew allowed with the API. Flows created or adopted earlier
+// through the client are never candidates, so resources sharing a document
+// don't adopt each other's flow. Nil is returned when there is no such flow,
+// and an error when the flows can't be listed or more than one matches,
+// since creating again could duplicate it.
+func (c *TruoraClient) adoptCreatedFlow(ctx context.Context, flow *IdentityProcessFlow, since time.Time) (*IdentityProcessFlowResponse, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), adoptionTimeout)
+	defer cancel()
+
+	flows, err := c.ListFlows(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error looking for a flow created by a failed request: %w", err)
+	}
+
+	hash := flowContentHash(flow.Name, flow.Type, flow.Config, flow.IdentityVerifications)
+
+	var candidates []*IdentityProcessFlowResponse
+	for _, existing := range flows {
+		if existing.Name != flow.Name || existing.CreationDate == nil || existing.CreationDate.Before(since.Add(-adoptionClockSkew)) {
+			continue
+		}
+
+		if _, created := c.createdFlows.Load(existing.FlowID); created {
+			continue
+		}
+
+		if flowContentHash(existing.Name, existing.Type, existing.Config, existing.IdentityVerifications) == hash {
+			candidates = append(candidates, existing)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, nil
+	case 1:
+		tflog.Warn(ctx, "Adopting flow created by a request that failed", map[string]interface{}{
+			"flow_id": candidates[0].FlowID,
+		})
+
+		return candidates[0], nil
+	default:
+		return nil, fmt.Errorf("%d flows named %q were created by failed requests", len(candidates), flow.Name)
+	}
+}
@@ -0,0 +1,361 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+func TestCreateFlowAdoptsFlowAfterLostResponse(t *testing.T) {
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	server.InjectFault(truoratest.Fault{
+		Method:        http.MethodPost,
+		PathPrefix:    "/v1/flows",
+		StatusCode:    http.StatusGatewayTimeout,
+		Times:         1,
+		AfterHandling: true,
+	})
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := client.CreateFlow(context.Background(), &truora.IdentityProcessFlow{
+		Name: "adopted",
+		Type: "permanent",
+		IdentityVerifications: []*truora.IdentityVerification{
+			{Name: "document-validation", Steps: []*truora.Step{{Type: "document-validation"}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flows := server.Flows()
+	if len(flows) != 1 {
+		t.Fatalf("expected a single flow, got %d", len(flows))
+	}
+
+	if created.FlowID != flows[0].FlowID {
+		t.Fatalf("expected flow %s to be adopted, got %s", flows[0].FlowID, created.FlowID)
+	}
+}
+
+func TestCreateFlowDoesNotAdoptDifferentFlow(t *testing.T) {
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	existing := server.AddFlow(&truora.IdentityProcessFlow{Name: "existing", Type: "temporary"})
+
+	server.InjectFault(truoratest.Fault{
+		Method:     http.MethodPost,
+		PathPrefix: "/v1/flows",
+		StatusCode: http.StatusBadGateway,
+		Times:      1,
+	})
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := client.CreateFlow(context.Background(), &truora.IdentityProcessFlow{
+		Name: "existing",
+		Type: "permanent",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.FlowID == existing.FlowID {
+		t.Fatal("expected a flow with different content not to be adopted")
+	}
+
+	if flows := server.Flows(); len(flows) != 2 {
+		t.Fatalf("expected two flows, got %d", len(flows))
+	}
+}
+
+func TestCreateFlowDoesNotAdoptFlowCreatedBefore(t *testing.T) {
+	var offset atomic.Int64
+	offset.Store(int64(-time.Hour))
+
+	server := truoratest.NewServer(truoratest.WithClock(func() time.Time {
+		return time.Now().Add(time.Duration(offset.Load()))
+	}))
+	defer server.Close()
+
+	existing := server.AddFlow(&truora.IdentityProcessFlow{Name: "existing", Type: "permanent"})
+
+	offset.Store(0)
+
+	server.InjectFault(truoratest.Fault{
+		Method:     http.MethodPost,
+		PathPrefix: "/v1/flows",
+		StatusCode: http.StatusBadGateway,
+		Times:      1,
+	})
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := client.CreateFlow(context.Background(), &truora.IdentityProcessFlow{
+		Name: "existing",
+		Type: "permanent",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.FlowID == existing.FlowID {
+		t.Fatal("expected a flow created before the request not to be adopted")
+	}
+
+	if flows := server.Flows(); len(flows) != 2 {
+		t.Fatalf("expected two flows, got %d", len(flows))
+	}
+}
+
+func TestCreateFlowGivesUp(t *testing.T) {
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	server.InjectFault(truoratest.Fault{
+		Method:     http.MethodPost,
+		PathPrefix: "/v1/flows",
+		StatusCode: http.StatusBadGateway,
+	})
+
+	var creates atomic.Int32
+
+	client, err := server.NewClient(truora.WithMiddleware(countCreates(&creates)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.CreateFlow(context.Background(), &truora.IdentityProcessFlow{Name: "failing", Type: "permanent"})
+	if err == nil {
+		t.Fatal("expected the create to fail")
+	}
+
+	if creates.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %d", creates.Load())
+	}
+}
+
+func TestCreateFlowTransportErrors(t *testing.T) {
+	errReset := errors.New("connection reset by peer")
+
+	cases := []struct {
+		name    string
+		send    bool
+		flows   int
+		creates int32
+		err     bool
+	}{
+		{
+			// the request was written and the flow created, but the response was lost
+			name:    "after the request was written",
+			send:    true,
+			flows:   1,
+			creates: 1,
+		},
+		{
+			// like a DNS failure or a refused connection
+			name:    "before the request was written",
+			creates: 1,
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := truoratest.NewServer()
+			defer server.Close()
+
+			var creates atomic.Int32
+
+			failCreate := func(next http.RoundTripper) http.RoundTripper {
+				return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPost {
+						return next.RoundTrip(req)
+					}
+
+					if tc.send {
+						resp, err := next.RoundTrip(req)
+						if err != nil {
+							return nil, err
+						}
+
+						resp.Body.Close()
+					}
+
+					return nil, errReset
+				})
+			}
+
+			client, err := server.NewClient(truora.WithMiddleware(countCreates(&creates), failCreate))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			created, err := client.CreateFlow(context.Background(), &truora.IdentityProcessFlow{Name: "reset", Type: "permanent"})
+			if tc.err != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			flows := server.Flows()
+			if len(flows) != tc.flows {
+				t.Fatalf("expected %d flows, got %d", tc.flows, len(flows))
+			}
+
+			if tc.flows == 1 && created.FlowID != flows[0].FlowID {
+				t.Fatalf("expected flow %s to be adopted, got %s", flows[0].FlowID, created.FlowID)
+			}
+
+			if creates.Load() != tc.creates {
+				t.Fatalf("expected %d attempts, got %d", tc.creates, creates.Load())
+			}
+		})
+	}
+}
+
+func TestCreateFlowAdoptsWithSkewedServerClock(t *testing.T) {
+	// the API clock is behind, so the lost flow looks created before the request
+	server := truoratest.NewServer(truoratest.WithClock(func() time.Time {
+		return time.Now().Add(-10 * time.Second)
+	}))
+	defer server.Close()
+
+	server.InjectFault(truoratest.Fault{
+		Method:        http.MethodPost,
+		PathPrefix:    "/v1/flows",
+		StatusCode:    http.StatusGatewayTimeout,
+		Times:         1,
+		AfterHandling: true,
+	})
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := client.CreateFlow(context.Background(), &truora.IdentityProcessFlow{Name: "skewed", Type: "permanent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flows := server.Flows()
+	if len(flows) != 1 || created.FlowID != flows[0].FlowID {
+		t.Fatalf("expected the lost flow to be adopted, got %s and %d flows", created.FlowID, len(flows))
+	}
+}
+
+func TestCreateFlowDoesNotAdoptFlowCreatedByClient(t *testing.T) {
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// two resources sharing one document
+	first, err := client.CreateFlow(context.Background(), &truora.IdentityProcessFlow{Name: "shared", Type: "permanent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.InjectFault(truoratest.Fault{
+		Method:     http.MethodPost,
+		PathPrefix: "/v1/flows",
+		StatusCode: http.StatusBadGateway,
+		Times:      1,
+	})
+
+	second, err := client.CreateFlow(context.Background(), &truora.IdentityProcessFlow{Name: "shared", Type: "permanent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if second.FlowID == first.FlowID {
+		t.Fatal("expected the flow of the first create not to be adopted")
+	}
+
+	if flows := server.Flows(); len(flows) != 2 {
+		t.Fatalf("expected two flows, got %d", len(flows))
+	}
+}
+
+func TestCreateFlowIdempotencyKey(t *testing.T) {
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	server.InjectFault(truoratest.Fault{
+		Method:     http.MethodPost,
+		PathPrefix: "/v1/flows",
+		StatusCode: http.StatusBadGateway,
+		Times:      1,
+	})
+
+	var keys []string
+
+	recordKeys := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPost {
+				keys = append(keys, req.Header.Get("Idempotency-Key"))
+			}
+
+			return next.RoundTrip(req)
+		})
+	}
+
+	client, err := server.NewClient(truora.WithMiddleware(recordKeys))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first call is retried once, the second is a separate create of the
+	// same content, like a replaced resource
+	for i := 0; i < 2; i++ {
+		if _, err := client.CreateFlow(context.Background(), &truora.IdentityProcessFlow{Name: "keyed", Type: "permanent"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(keys) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(keys))
+	}
+
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Fatalf("expected the retry to reuse the key, got %q and %q", keys[0], keys[1])
+	}
+
+	if keys[2] == keys[0] {
+		t.Fatal("expected separate creates to have different keys")
+	}
+}
+
+// countCreates counts the create requests sent through the client
+func countCreates(creates *atomic.Int32) truora.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPost {
+				creates.Add(1)
+			}
+
+			return next.RoundTrip(req)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// LogBodiesEnvironmentVariableName enables logging request and response
+	// bodies at TRACE level, with PII fields masked
+	LogBodiesEnvironmentVariableName = "TRUORA_LOG_BODIES"
+)
+
+// requestIDHeaders are the response headers that can carry the request ID,
+// the first one present is logged
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "X-Amz-Apigw-Id"}
+
+type attemptContextKey struct{}
+
+// contextWithAttempt records which attempt of a request is being sent, so
+// retried requests can be told apart in the logs
+func contextWithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptContextKey{}, attempt)
+}
+
+func attemptFromContext(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptContextKey{}).(int); ok {
+		return attempt
+	}
+
+	return 1
+}
+
+// loggingTransport logs every request through tflog, so they show up with
+// TF_LOG=DEBUG. Credentials are never logged and bodies are only logged when
+// logBodies is set.
+type loggingTransport struct {
+	next      http.RoundTripper
+	logBodies bool
+}
+
+func newLoggingTransport(next http.RoundTripper, logBodies bool) *loggingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &loggingTransport{
+		next:      next,
+		logBodies: logBodies,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_host":   req.URL.Host,
+		"http_path":   req.URL.Path,
+		"attempt":     attemptFromContext(ctx),
+	}
+
+	if req.URL.RawQuery != "" {
+		fields["http_query"] = redactValues(req.URL.Query()).Encode()
+	}
+
+	if t.logBodies {
+		body, err := readRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+
+		tflog.Trace(ctx, "Truora API request body", withFields(fields, map[string]interface{}{
+			"http_headers": redactHeaders(req.Header),
+			"http_body":    string(redactBody(req.Header.Get("Content-Type"), body)),
+		}))
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		tflog.Debug(ctx, "Truora API request failed", withFields(fields, map[string]interface{}{
+			"error": err.Error(),
+		}))
+
+		return nil, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+
+	for _, header := range requestIDHeaders {
+		if requestID := resp.Header.Get(header); requestID != "" {
+			fields["request_id"] = requestID
+			break
+		}
+	}
+
+	tflog.Debug(ctx, "Truora API request", fields)
+
+	if t.logBodies {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		tflog.Trace(ctx, "Truora API response body", withFields(fields, map[string]interface{}{
+			"http_headers": redactHeaders(resp.Header),
+			"http_body":    string(redactBody(resp.Header.Get("Content-Type"), body)),
+		}))
+	}
+
+	return resp, nil
+}
+
+func withFields(fields, extra map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(fields)+len(extra))
+	for k, v := range fields {
+		result[k] = v
+	}
+
+	for k, v := range extra {
+		result[k] = v
+	}
+
+	return result
+}
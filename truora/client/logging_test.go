@@ -0,0 +1,137 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+func TestLoggingRedactsRequest(t *testing.T) {
+	t.Setenv(truora.LogBodiesEnvironmentVariableName, "1")
+
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	_, err = client.CreateHook(ctx, &truora.Hook{
+		EventType:   "identity_process",
+		EventAction: "succeeded",
+		URL:         "https://example.com/hooks",
+		AuthType:    "basic",
+		Username:    "truora",
+		Password:    "hook-password",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{truoratest.APIKey, "hook-password"} {
+		if strings.Contains(output.String(), secret) {
+			t.Fatalf("expected %q to be masked in the logs:\n%s", secret, output.String())
+		}
+	}
+
+	entry := findLogEntry(t, &output, "Truora API request body")
+
+	headers := entry["http_headers"].(map[string]interface{})
+	if apiKey := headers["Truora-Api-Key"].([]interface{}); apiKey[0] != "REDACTED" {
+		t.Fatalf("expected the API key header to be masked, got %v", apiKey)
+	}
+
+	if body := entry["http_body"].(string); !strings.Contains(body, "password=REDACTED") {
+		t.Fatalf("expected the password to be masked, got %s", body)
+	}
+}
+
+func TestLoggingRedactsResponse(t *testing.T) {
+	t.Setenv(truora.LogBodiesEnvironmentVariableName, "1")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+  "process_id": "IDP1",
+  "status": "success",
+  "phone": 573001234567,
+  "address": {"street": "Calle 1 # 2-3", "city": "Bogota"},
+  "document_number": null,
+  "validations": [{"validation_id": "VLD1", "national_id": 1020304050}]
+}`))
+	}))
+	defer server.Close()
+
+	client, err := truora.NewClient(
+		truora.WithAPIKey("logging-key"),
+		truora.WithEndpoints(truora.Endpoints{Identity: server.URL}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	if _, err := client.GetProcessResult(ctx, "IDP1"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"logging-key", "573001234567", "Calle 1", "Bogota", "1020304050"} {
+		if strings.Contains(output.String(), secret) {
+			t.Fatalf("expected %q to be masked in the logs:\n%s", secret, output.String())
+		}
+	}
+
+	entry := findLogEntry(t, &output, "Truora API response body")
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(entry["http_body"].(string)), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	if body["phone"] != "REDACTED" || body["address"] != "REDACTED" {
+		t.Fatalf("expected the phone and address to be masked, got %v", body)
+	}
+
+	if body["document_number"] != nil {
+		t.Fatalf("expected null to be kept, got %v", body["document_number"])
+	}
+
+	if body["process_id"] != "IDP1" {
+		t.Fatalf("expected other fields to be kept, got %v", body)
+	}
+}
+
+// findLogEntry returns the first log entry with message
+func findLogEntry(t *testing.T, output *bytes.Buffer, message string) map[string]interface{} {
+	t.Helper()
+
+	entries, err := tflogtest.MultilineJSONDecode(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range entries {
+		if entry["@message"] == message {
+			return entry
+		}
+	}
+
+	t.Fatalf("log entry %q not found", message)
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Approval statuses WhatsApp assigns to outbound templates
+const (
+	OutboundApprovalStatusPending  = "PENDING"
+	OutboundApprovalStatusApproved = "APPROVED"
+	OutboundApprovalStatusRejected = "REJECTED"
+)
+
+// OutboundTemplate is a TruConnect WhatsApp outbound message template
+type OutboundTemplate struct {
+	OutboundID      string   `json:"outbound_id,omitempty"`
+	Name            string   `json:"name"`
+	Language        string   `json:"language"`
+	Body            string   `json:"body"`
+	Variables       []string `json:"variables,omitempty"`
+	FlowID          string   `json:"flow_id,omitempty"`
+	PhoneNumber     string   `json:"phone_number"`
+	ApprovalStatus  string   `json:"approval_status,omitempty"`
+	RejectionReason string   `json:"rejection_reason,omitempty"`
+}
+
+func (c *TruoraClient) GetOutboundTemplate(ctx context.Context, outboundID string) (*OutboundTemplate, error) {
+	client := c.HTTPClient
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/v1/whatsapp/outbounds/%s", c.Endpoints.Connect, outboundID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error getting outbound template: %s\n%s", resp.Status, stringBody)
+	}
+
+	var outbound OutboundTemplate
+	if err := json.NewDecoder(resp.Body).Decode(&outbound); err != nil {
+		return nil, err
+	}
+
+	return &outbound, nil
+}
+
+func (c *TruoraClient) CreateOutboundTemplate(ctx context.Context, outbound *OutboundTemplate) (*OutboundTemplate, error) {
+	client := c.HTTPClient
+
+	marshalledOutbound, err := json.Marshal(outbound)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/v1/whatsapp/outbounds", c.Endpoints.Connect), bytes.NewBuffer(marshalledOutbound))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error creating outbound template: %s\n%s", resp.Status, stringBody)
+	}
+
+	var outboundResponse OutboundTemplate
+	if err := json.NewDecoder(resp.Body).Decode(&outboundResponse); err != nil {
+		return nil, err
+	}
+
+	return &outboundResponse, nil
+}
+
+func (c *TruoraClient) UpdateOutboundTemplate(ctx context.Context, outboundID string, outbound *OutboundTemplate) (*OutboundTemplate, error) {
+	client := c.HTTPClient
+
+	marshalledOutbound, err := json.Marshal(outbound)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/v1/whatsapp/outbounds/%s", c.Endpoints.Connect, outboundID), bytes.NewBuffer(marshalledOutbound))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error updating outbound template: %s\n%s", resp.Status, stringBody)
+	}
+
+	var outboundResponse OutboundTemplate
+	if err := json.NewDecoder(resp.Body).Decode(&outboundResponse); err != nil {
+		return nil, err
+	}
+
+	return &outboundResponse, nil
+}
+
+func (c *TruoraClient) DeleteOutboundTemplate(ctx context.Context, outboundID string) error {
+	client := c.HTTPClient
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/v1/whatsapp/outbounds/%s", c.Endpoints.Connect, outboundID), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return fmt.Errorf("error deleting outbound template: %s\n%s", resp.Status, stringBody)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ProcessValidation is the result of one of the validations run in a process
+type ProcessValidation struct {
+	ValidationID     string     `json:"validation_id"`
+	Type             string     `json:"type"`
+	ValidationStatus string     `json:"validation_status"`
+	FailureStatus    string     `json:"failure_status,omitempty"`
+	CreationDate     *time.Time `json:"creation_date,omitempty"`
+	UpdateDate       *time.Time `json:"update_date,omitempty"`
+}
+
+// ProcessResult is the result of a Digital Identity process
+type ProcessResult struct {
+	ProcessID      string               `json:"process_id"`
+	FlowID         string               `json:"flow_id"`
+	ClientID       string               `json:"client_id"`
+	Status         string               `json:"status"`
+	FailureStatus  string               `json:"failure_status,omitempty"`
+	DeclinedReason string               `json:"declined_reason,omitempty"`
+	CreationDate   *time.Time           `json:"creation_date,omitempty"`
+	UpdateDate     *time.Time           `json:"update_date,omitempty"`
+	Validations    []*ProcessValidation `json:"validations,omitempty"`
+}
+
+func (c *TruoraClient) GetProcessResult(ctx context.Context, processID string) (*ProcessResult, error) {
+	client := c.HTTPClient
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/v1/processes/%s/result", c.Endpoints.Identity, processID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Truora-Api-Key", c.APIKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		stringBody := new(bytes.Buffer)
+		stringBody.ReadFrom(resp.Body)
+		return nil, fmt.Errorf("error getting process result: %s\n%s", resp.Status, stringBody)
+	}
+
+	var process ProcessResult
+	if err := json.NewDecoder(resp.Body).Decode(&process); err != nil {
+		return nil, err
+	}
+
+	return &process, nil
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// maxRateLimitedAttempts is how many times a request is sent when the API
+	// keeps answering 429 Too Many Requests
+	maxRateLimitedAttempts = 4
+
+	defaultRetryAfter = time.Second
+	maxRetryAfter     = time.Minute
+
+	unixTimeThreshold = 1_000_000_000
+)
+
+// WithRateLimit limits the client to requestsPerSecond with bursts of up to
+// burst requests. The limit is shared by every request of the client, so it
+// also holds when Terraform runs operations in parallel.
+func WithRateLimit(requestsPerSecond float64, burst int) TruoraClientOption {
+	return func(client *TruoraClient) {
+		if burst < 1 {
+			burst = 1
+		}
+
+		client.limiter = newRateLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+}
+
+// rateLimiter is a token bucket that can also be paused until a point in
+// time, when the API reports the rate limit was exhausted
+type rateLimiter struct {
+	limiter *rate.Limiter
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+func newRateLimiter(limit rate.Limit, burst int) *rateLimiter {
+	return &rateLimiter{limiter: rate.NewLimiter(limit, burst)}
+}
+
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	pause := time.Until(l.pausedUntil)
+	l.mu.Unlock()
+
+	if pause > 0 {
+		if err := sleep(ctx, pause); err != nil {
+			return err
+		}
+	}
+
+	return l.limiter.Wait(ctx)
+}
+
+// pause holds every request until the given time, earlier pauses don't
+// shorten a longer one
+func (l *rateLimiter) pause(until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// observe adapts the limiter to the rate limit headers of a response, when
+// no requests are left it pauses until the limit resets
+func (l *rateLimiter) observe(resp *http.Response) {
+	if resp.Header.Get("X-Ratelimit-Remaining") != "0" {
+		return
+	}
+
+	if reset := parseRateLimitReset(resp.Header.Get("X-Ratelimit-Reset")); reset > 0 {
+		l.pause(time.Now().Add(reset))
+	}
+}
+
+// rateLimitTransport waits for the limiter before each request and retries
+// requests answered with 429 Too Many Requests after the time the API asks for
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rateLimiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		attemptReq, err := requestForAttempt(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if err != nil {
+			return nil, err
+		}
+
+		t.limiter.observe(resp)
+
+		if resp.StatusCode != http.StatusTooManyRequests || attempt == maxRateLimitedAttempts || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+
+		t.limiter.pause(time.Now().Add(parseRetryAfter(resp.Header.Get("Retry-After"))))
+
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
+
+// requestForAttempt returns req with the attempt in its context and, for
+// retries, a fresh copy of its body
+func requestForAttempt(req *http.Request, attempt int) (*http.Request, error) {
+	attemptReq := req.Clone(contextWithAttempt(req.Context(), attempt))
+
+	if attempt > 1 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		attemptReq.Body = body
+	}
+
+	return attemptReq, nil
+}
+
+// parseRetryAfter reads a Retry-After header in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return defaultRetryAfter
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return clampRetryAfter(time.Duration(seconds) * time.Second)
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return clampRetryAfter(time.Until(date))
+	}
+
+	return defaultRetryAfter
+}
+
+// parseRateLimitReset reads a X-RateLimit-Reset header, either the seconds
+// until the reset or the unix time of the reset
+func parseRateLimitReset(value string) time.Duration {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	// values this large can only be unix times
+	if seconds > unixTimeThreshold {
+		return clampRetryAfter(time.Until(time.Unix(seconds, 0)))
+	}
+
+	return clampRetryAfter(time.Duration(seconds) * time.Second)
+}
+
+func clampRetryAfter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return defaultRetryAfter
+	}
+
+	if d > maxRetryAfter {
+		return maxRetryAfter
+	}
+
+	return d
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
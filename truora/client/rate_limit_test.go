@@ -0,0 +1,44 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+func TestRateLimitRetriesTooManyRequests(t *testing.T) {
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	flow := server.AddFlow(&truora.IdentityProcessFlow{Name: "rate-limited", Type: "permanent"})
+
+	server.InjectFault(truoratest.Fault{
+		Method:     http.MethodGet,
+		StatusCode: http.StatusTooManyRequests,
+		Times:      1,
+	})
+
+	client, err := server.NewClient(truora.WithRateLimit(10, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+
+	got, err := client.GetFlow(context.Background(), flow.FlowID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.FlowID != flow.FlowID {
+		t.Fatalf("expected flow %s, got %s", flow.FlowID, got.FlowID)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected the retry to wait for Retry-After, took %s", elapsed)
+	}
+}
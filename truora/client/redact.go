@@ -0,0 +1,131 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const redactedValue = "REDACTED"
+
+// piiFields are the request and response fields that can identify a person,
+// their values are masked before being written to cassettes or logs
+var piiFields = map[string]bool{
+	"phone":           true,
+	"phone_number":    true,
+	"cellphone":       true,
+	"email":           true,
+	"national_id":     true,
+	"document_number": true,
+	"document_id":     true,
+	"first_name":      true,
+	"last_name":       true,
+	"full_name":       true,
+	"date_of_birth":   true,
+	"birth_date":      true,
+	"address":         true,
+	"password":        true,
+	"auth_token":      true,
+	"api_key":         true,
+}
+
+// sensitiveHeaders are masked entirely
+var sensitiveHeaders = []string{"Truora-Api-Key", "Authorization", "Cookie", "Set-Cookie"}
+
+func isPIIField(name string) bool {
+	return piiFields[strings.ToLower(name)]
+}
+
+// redactHeaders returns a copy of headers with the sensitive ones masked
+func redactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	if redacted == nil {
+		return http.Header{}
+	}
+
+	for _, name := range sensitiveHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, redactedValue)
+		}
+	}
+
+	return redacted
+}
+
+// redactURL masks the PII query parameters of u
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = redactValues(u.Query()).Encode()
+
+	return redacted.String()
+}
+
+func redactValues(values url.Values) url.Values {
+	redacted := url.Values{}
+	for key, v := range values {
+		if isPIIField(key) {
+			redacted[key] = []string{redactedValue}
+			continue
+		}
+
+		redacted[key] = v
+	}
+
+	return redacted
+}
+
+// redactBody masks PII fields in JSON and form encoded bodies. Other bodies
+// are returned unchanged.
+func redactBody(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+
+		return []byte(redactValues(values).Encode())
+	}
+
+	var document interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return body
+	}
+
+	redacted, err := json.Marshal(redactJSONValue(document))
+	if err != nil {
+		return body
+	}
+
+	return redacted
+}
+
+// redactJSONValue masks every non null value under a PII field, numbers and
+// nested objects included, and walks the rest of the document
+func redactJSONValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if item != nil && isPIIField(key) {
+				value[key] = redactedValue
+				continue
+			}
+
+			value[key] = redactJSONValue(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactJSONValue(item)
+		}
+	}
+
+	return v
+}
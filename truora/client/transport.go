@@ -0,0 +1,144 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// DefaultUserAgent is sent when no user agent is set with WithUserAgent
+const DefaultUserAgent = "terraform-provider-truora"
+
+// Middleware wraps the transport of the client, to inspect or change every
+// request it sends
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// WithHTTPClient replaces the HTTP client used to call the API. The client is
+// copied so its transport isn't changed by the other options.
+func WithHTTPClient(httpClient *http.Client) TruoraClientOption {
+	return func(client *TruoraClient) {
+		httpClientCopy := *httpClient
+		client.HTTPClient = &httpClientCopy
+	}
+}
+
+// WithTransport replaces the transport of the HTTP client
+func WithTransport(transport http.RoundTripper) TruoraClientOption {
+	return func(client *TruoraClient) {
+		client.HTTPClient.Transport = transport
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent on every request
+func WithUserAgent(userAgent string) TruoraClientOption {
+	return func(client *TruoraClient) {
+		client.UserAgent = userAgent
+	}
+}
+
+// WithMiddleware adds middlewares to the transport. Middlewares wrap each
+// other in the order they are added, so the first one sees requests first.
+func WithMiddleware(middlewares ...Middleware) TruoraClientOption {
+	return func(client *TruoraClient) {
+		client.middlewares = append(client.middlewares, middlewares...)
+	}
+}
+
+// TransportOptions configures the network settings of NewTransport
+type TransportOptions struct {
+	ProxyURL           string
+	CACertPEM          string
+	ClientCertPEM      string
+	ClientKeyPEM       string
+	InsecureSkipVerify bool
+}
+
+// NewTransport returns a copy of http.DefaultTransport with the proxy, CA
+// bundle and client certificate of opts. The CA bundle is added to the system
+// roots instead of replacing them.
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.InsecureSkipVerify, // #nosec G402 -- opt-in for test environments
+	}
+
+	if opts.CACertPEM != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if !rootCAs.AppendCertsFromPEM([]byte(opts.CACertPEM)) {
+			return nil, fmt.Errorf("no certificates found in the CA bundle")
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if opts.ClientCertPEM != "" || opts.ClientKeyPEM != "" {
+		if opts.ClientCertPEM == "" || opts.ClientKeyPEM == "" {
+			return nil, fmt.Errorf("the client certificate and key must be set together")
+		}
+
+		certificate, err := tls.X509KeyPair([]byte(opts.ClientCertPEM), []byte(opts.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// userAgentTransport sets the User-Agent header of every request
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+
+	return t.next.RoundTrip(req)
+}
+
+// buildTransport chains the transport of the HTTP client with the client
+// middlewares. From the outside in: rate limiting, tracing, logging, user
+// agent, middlewares and the configured transport.
+func (c *TruoraClient) buildTransport(logBodies bool) {
+	transport := c.HTTPClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		transport = c.middlewares[i](transport)
+	}
+
+	transport = &userAgentTransport{next: transport, userAgent: c.UserAgent}
+
+	// logging wraps everything else so replayed requests are logged too
+	transport = newLoggingTransport(transport, logBodies)
+
+	transport = &tracingTransport{next: transport}
+
+	// rate limiting is outside logging and tracing so every retry is recorded
+	c.HTTPClient.Transport = &rateLimitTransport{next: transport, limiter: c.limiter}
+}
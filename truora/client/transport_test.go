@@ -0,0 +1,204 @@
+package client_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+func TestNewTransport(t *testing.T) {
+	certPEM, keyPEM, cert := testCertificate(t)
+	_, otherKeyPEM, _ := testCertificate(t)
+
+	cases := []struct {
+		name  string
+		opts  truora.TransportOptions
+		err   string
+		check func(t *testing.T, transport *http.Transport)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, transport *http.Transport) {
+				tlsConfig := transport.TLSClientConfig
+				if tlsConfig.MinVersion != tls.VersionTLS12 || tlsConfig.InsecureSkipVerify || tlsConfig.RootCAs != nil || len(tlsConfig.Certificates) != 0 {
+					t.Fatalf("expected the default TLS settings, got %+v", tlsConfig)
+				}
+			},
+		},
+		{
+			name: "proxy",
+			opts: truora.TransportOptions{ProxyURL: "http://proxy.example.com:3128"},
+			check: func(t *testing.T, transport *http.Transport) {
+				req, _ := http.NewRequest(http.MethodGet, "https://api.identity.truora.com/v1/flows", nil)
+
+				proxyURL, err := transport.Proxy(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if proxyURL == nil || proxyURL.String() != "http://proxy.example.com:3128" {
+					t.Fatalf("expected requests to go through the proxy, got %v", proxyURL)
+				}
+			},
+		},
+		{
+			name: "invalid proxy",
+			opts: truora.TransportOptions{ProxyURL: "://proxy"},
+			err:  "invalid proxy URL",
+		},
+		{
+			name: "CA bundle",
+			opts: truora.TransportOptions{CACertPEM: certPEM},
+			check: func(t *testing.T, transport *http.Transport) {
+				if _, err := cert.Verify(x509.VerifyOptions{Roots: transport.TLSClientConfig.RootCAs, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}); err != nil {
+					t.Fatalf("expected the CA to be trusted: %s", err)
+				}
+			},
+		},
+		{
+			name: "CA bundle without certificates",
+			opts: truora.TransportOptions{CACertPEM: "not a certificate"},
+			err:  "no certificates found in the CA bundle",
+		},
+		{
+			name: "client certificate",
+			opts: truora.TransportOptions{ClientCertPEM: certPEM, ClientKeyPEM: keyPEM},
+			check: func(t *testing.T, transport *http.Transport) {
+				certificates := transport.TLSClientConfig.Certificates
+				if len(certificates) != 1 || !certificates[0].Leaf.Equal(cert) {
+					t.Fatalf("expected the client certificate, got %d certificates", len(certificates))
+				}
+			},
+		},
+		{
+			name: "client certificate without key",
+			opts: truora.TransportOptions{ClientCertPEM: certPEM},
+			err:  "the client certificate and key must be set together",
+		},
+		{
+			name: "client key without certificate",
+			opts: truora.TransportOptions{ClientKeyPEM: keyPEM},
+			err:  "the client certificate and key must be set together",
+		},
+		{
+			name: "client key of another certificate",
+			opts: truora.TransportOptions{ClientCertPEM: certPEM, ClientKeyPEM: otherKeyPEM},
+			err:  "invalid client certificate",
+		},
+		{
+			name: "insecure skip verify",
+			opts: truora.TransportOptions{InsecureSkipVerify: true},
+			check: func(t *testing.T, transport *http.Transport) {
+				if !transport.TLSClientConfig.InsecureSkipVerify {
+					t.Fatal("expected certificate verification to be skipped")
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			transport, err := truora.NewTransport(tc.opts)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected an error containing %q, got %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if transport == http.DefaultTransport {
+				t.Fatal("expected a copy of the default transport")
+			}
+
+			tc.check(t, transport)
+		})
+	}
+}
+
+func TestNewTransportTrustsCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	for _, opts := range []truora.TransportOptions{{}, {CACertPEM: caPEM}} {
+		transport, err := truora.NewTransport(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		if opts.CACertPEM == "" {
+			if err == nil {
+				resp.Body.Close()
+				t.Fatal("expected the certificate of the test server not to be trusted without the CA bundle")
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp.Body.Close()
+	}
+}
+
+// testCertificate returns a self signed CA certificate and its key as PEM
+func testCertificate(t *testing.T) (string, string, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "truora test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM), cert
+}
@@ -0,0 +1,134 @@
+package truoratest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+// CheckCustomType returns a copy of a stored check custom type
+func (s *Server) CheckCustomType(country, customType string) (*truora.CheckCustomType, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkCustomType, ok := s.checkCustomTypes[checkCustomTypeKey(country, customType)]
+	if !ok {
+		return nil, false
+	}
+
+	return copyCheckCustomType(checkCustomType), true
+}
+
+func (s *Server) handleCheckCustomTypes(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodDelete:
+		query := r.URL.Query()
+		key := checkCustomTypeKey(query.Get("country"), query.Get("type"))
+
+		s.mu.Lock()
+		checkCustomType, found := s.checkCustomTypes[key]
+		if found && r.Method == http.MethodDelete {
+			delete(s.checkCustomTypes, key)
+		}
+		s.mu.Unlock()
+
+		if !found {
+			writeError(w, http.StatusNotFound, "custom type not found")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, checkCustomType)
+	case http.MethodPost, http.MethodPut:
+		checkCustomType, err := decodeCheckCustomType(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		key := checkCustomTypeKey(checkCustomType.Country, checkCustomType.Type)
+
+		s.mu.Lock()
+		_, found := s.checkCustomTypes[key]
+		if found == (r.Method == http.MethodPut) {
+			s.checkCustomTypes[key] = checkCustomType
+		}
+		s.mu.Unlock()
+
+		switch {
+		case found && r.Method == http.MethodPost:
+			writeError(w, http.StatusConflict, "custom type already exists")
+		case !found && r.Method == http.MethodPut:
+			writeError(w, http.StatusNotFound, "custom type not found")
+		default:
+			writeJSON(w, http.StatusOK, checkCustomType)
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	}
+}
+
+// decodeCheckCustomType reads the form encoded body the checks API expects,
+// dataset weights are sent as dataset_weights[i][dataset] and
+// dataset_weights[i][weight] starting at 0
+func decodeCheckCustomType(r *http.Request) (*truora.CheckCustomType, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return nil, fmt.Errorf("expected a form encoded body, got %q", r.Header.Get("Content-Type"))
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	checkCustomType := &truora.CheckCustomType{
+		Country: r.PostForm.Get("country"),
+		Type:    r.PostForm.Get("type"),
+	}
+
+	if checkCustomType.Country == "" || checkCustomType.Type == "" {
+		return nil, fmt.Errorf("country and type are required")
+	}
+
+	for i := 0; ; i++ {
+		dataset := r.PostForm.Get(fmt.Sprintf("dataset_weights[%d][dataset]", i))
+		weight := r.PostForm.Get(fmt.Sprintf("dataset_weights[%d][weight]", i))
+
+		if dataset == "" && weight == "" {
+			break
+		}
+
+		value, err := strconv.ParseFloat(weight, 64)
+		if err != nil || dataset == "" {
+			return nil, fmt.Errorf("invalid dataset weight %d", i)
+		}
+
+		checkCustomType.DatasetWeights = append(checkCustomType.DatasetWeights, &truora.DatasetWeight{
+			Dataset: dataset,
+			Weight:  value,
+		})
+	}
+
+	if len(checkCustomType.DatasetWeights) == 0 {
+		return nil, fmt.Errorf("at least one dataset weight is required")
+	}
+
+	return checkCustomType, nil
+}
+
+func checkCustomTypeKey(country, customType string) string {
+	return country + "/" + customType
+}
+
+func copyCheckCustomType(checkCustomType *truora.CheckCustomType) *truora.CheckCustomType {
+	checkCustomTypeCopy := *checkCustomType
+	checkCustomTypeCopy.DatasetWeights = nil
+
+	for _, datasetWeight := range checkCustomType.DatasetWeights {
+		datasetWeightCopy := *datasetWeight
+		checkCustomTypeCopy.DatasetWeights = append(checkCustomTypeCopy.DatasetWeights, &datasetWeightCopy)
+	}
+
+	return &checkCustomTypeCopy
+}
@@ -0,0 +1,142 @@
+package truoratest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+// ContinuousCheck returns a copy of a stored continuous check, canceled
+// checks are kept like in the API
+func (s *Server) ContinuousCheck(continuousCheckID string) (*truora.ContinuousCheck, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	continuousCheck, ok := s.continuousChecks[continuousCheckID]
+	if !ok {
+		return nil, false
+	}
+
+	continuousCheckCopy := *continuousCheck
+
+	return &continuousCheckCopy, true
+}
+
+// PutContinuousCheck stores a continuous check as is, replacing the one with
+// the same ID
+func (s *Server) PutContinuousCheck(continuousCheck *truora.ContinuousCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	continuousCheckCopy := *continuousCheck
+	s.continuousChecks[continuousCheck.ContinuousCheckID] = &continuousCheckCopy
+}
+
+func (s *Server) handleContinuousChecks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	now := s.now().UTC()
+
+	continuousCheck := &truora.ContinuousCheck{
+		ContinuousCheckID: newID("CNC"),
+		NationalID:        r.PostForm.Get("national_id"),
+		Country:           r.PostForm.Get("country"),
+		Type:              r.PostForm.Get("type"),
+		Frequency:         r.PostForm.Get("frequency"),
+		Status:            "active",
+		CreationDate:      &now,
+	}
+
+	if continuousCheck.NationalID == "" || continuousCheck.Country == "" || continuousCheck.Type == "" {
+		writeError(w, http.StatusBadRequest, "national_id, country and type are required")
+		return
+	}
+
+	nextRunDate, ok := nextContinuousCheckRun(now, continuousCheck.Frequency)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid frequency")
+		return
+	}
+
+	continuousCheck.NextRunDate = &nextRunDate
+
+	s.mu.Lock()
+	s.continuousChecks[continuousCheck.ContinuousCheckID] = continuousCheck
+	created := *continuousCheck
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) handleContinuousCheck(w http.ResponseWriter, r *http.Request) {
+	continuousCheckID := strings.TrimPrefix(r.URL.Path, "/v1/continuous-checks/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	continuousCheck, found := s.continuousChecks[continuousCheckID]
+	if !found {
+		writeError(w, http.StatusNotFound, "continuous check not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, continuousCheck)
+	case http.MethodPut:
+		if err := r.ParseForm(); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if continuousCheck.Status == truora.ContinuousCheckCanceledStatus {
+			writeError(w, http.StatusConflict, "continuous check is canceled")
+			return
+		}
+
+		frequency := r.PostForm.Get("frequency")
+
+		nextRunDate, ok := nextContinuousCheckRun(s.now().UTC(), frequency)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid frequency")
+			return
+		}
+
+		continuousCheck.Frequency = frequency
+		continuousCheck.NextRunDate = &nextRunDate
+
+		writeJSON(w, http.StatusOK, continuousCheck)
+	case http.MethodDelete:
+		continuousCheck.Status = truora.ContinuousCheckCanceledStatus
+		continuousCheck.NextRunDate = nil
+
+		writeJSON(w, http.StatusOK, continuousCheck)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	}
+}
+
+func nextContinuousCheckRun(from time.Time, frequency string) (time.Time, bool) {
+	switch frequency {
+	case "daily":
+		return from.AddDate(0, 0, 1), true
+	case "weekly":
+		return from.AddDate(0, 0, 7), true
+	case "biweekly":
+		return from.AddDate(0, 0, 14), true
+	case "monthly":
+		return from.AddDate(0, 1, 0), true
+	}
+
+	return time.Time{}, false
+}
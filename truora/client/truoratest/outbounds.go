@@ -0,0 +1,146 @@
+package truoratest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+// OutboundReview is how WhatsApp reviews outbound templates: a created or
+// edited template stays PENDING for Reads GET requests and then gets Status
+// and RejectionReason. An empty Status keeps templates pending forever.
+type OutboundReview struct {
+	Reads           int
+	Status          string
+	RejectionReason string
+}
+
+type storedOutbound struct {
+	outbound *truora.OutboundTemplate
+	reads    int
+}
+
+// SetOutboundReview changes how the templates created or edited from now on
+// are reviewed
+func (s *Server) SetOutboundReview(review OutboundReview) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outboundReview = review
+}
+
+// OutboundTemplate returns a copy of a stored outbound template
+func (s *Server) OutboundTemplate(outboundID string) (*truora.OutboundTemplate, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.outbounds[outboundID]
+	if !ok {
+		return nil, false
+	}
+
+	return copyOutbound(stored.outbound), true
+}
+
+func (s *Server) handleOutbounds(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		return
+	}
+
+	outbound, ok := decodeOutbound(w, r)
+	if !ok {
+		return
+	}
+
+	outbound.OutboundID = newID("OUT")
+	outbound.ApprovalStatus = truora.OutboundApprovalStatusPending
+
+	s.mu.Lock()
+	s.outbounds[outbound.OutboundID] = &storedOutbound{outbound: outbound}
+	created := copyOutbound(outbound)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) handleOutbound(w http.ResponseWriter, r *http.Request) {
+	outboundID := strings.TrimPrefix(r.URL.Path, "/v1/whatsapp/outbounds/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, found := s.outbounds[outboundID]
+	if !found {
+		writeError(w, http.StatusNotFound, "outbound not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.reviewOutbound(stored)
+		writeJSON(w, http.StatusOK, stored.outbound)
+	case http.MethodPut:
+		outbound, ok := decodeOutbound(w, r)
+		if !ok {
+			return
+		}
+
+		// edited templates are reviewed again
+		outbound.OutboundID = outboundID
+		outbound.ApprovalStatus = truora.OutboundApprovalStatusPending
+		stored.outbound = outbound
+		stored.reads = 0
+
+		writeJSON(w, http.StatusOK, outbound)
+	case http.MethodDelete:
+		delete(s.outbounds, outboundID)
+		writeJSON(w, http.StatusOK, map[string]string{"outbound_id": outboundID})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	}
+}
+
+// reviewOutbound moves a pending template to the review status once it has
+// been read enough times, s.mu must be held
+func (s *Server) reviewOutbound(stored *storedOutbound) {
+	if stored.outbound.ApprovalStatus != truora.OutboundApprovalStatusPending || s.outboundReview.Status == "" {
+		return
+	}
+
+	stored.reads++
+	if stored.reads <= s.outboundReview.Reads {
+		return
+	}
+
+	stored.outbound.ApprovalStatus = s.outboundReview.Status
+	stored.outbound.RejectionReason = s.outboundReview.RejectionReason
+}
+
+func decodeOutbound(w http.ResponseWriter, r *http.Request) (*truora.OutboundTemplate, bool) {
+	var outbound truora.OutboundTemplate
+	if err := json.NewDecoder(r.Body).Decode(&outbound); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	if outbound.Name == "" || outbound.Language == "" || outbound.Body == "" || outbound.PhoneNumber == "" {
+		writeError(w, http.StatusBadRequest, "name, language, body and phone_number are required")
+		return nil, false
+	}
+
+	// only WhatsApp sets them
+	outbound.ApprovalStatus = ""
+	outbound.RejectionReason = ""
+
+	return &outbound, true
+}
+
+func copyOutbound(outbound *truora.OutboundTemplate) *truora.OutboundTemplate {
+	outboundCopy := *outbound
+	outboundCopy.Variables = append([]string(nil), outbound.Variables...)
+
+	return &outboundCopy
+}
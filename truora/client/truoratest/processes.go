@@ -0,0 +1,55 @@
+package truoratest
+
+import (
+	"net/http"
+	"strings"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+// PutProcess stores the result of a process, as if a user went through its
+// flow, replacing the one with the same process ID
+func (s *Server) PutProcess(process *truora.ProcessResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.processes[process.ProcessID] = copyProcess(process)
+}
+
+func (s *Server) handleProcess(w http.ResponseWriter, r *http.Request) {
+	processID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/v1/processes/"), "/result")
+	if !ok || processID == "" || strings.Contains(processID, "/") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		return
+	}
+
+	s.mu.Lock()
+	process, found := s.processes[processID]
+	s.mu.Unlock()
+
+	if !found {
+		writeError(w, http.StatusNotFound, "process not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, process)
+}
+
+// copyProcess copies a process and its validations, dates are shared since
+// they are never changed
+func copyProcess(process *truora.ProcessResult) *truora.ProcessResult {
+	processCopy := *process
+	processCopy.Validations = nil
+
+	for _, validation := range process.Validations {
+		validationCopy := *validation
+		processCopy.Validations = append(processCopy.Validations, &validationCopy)
+	}
+
+	return &processCopy
+}
@@ -0,0 +1,721 @@
+// Package truoratest provides an in-memory fake of the Truora APIs used by the
+// provider for tests, so modules and the provider can be exercised without a real account.
+package truoratest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+// APIKey is the key the fake server accepts unless WithAPIKey is used
+const APIKey = "truoratest-api-key"
+
+// ChecksPath is where the checks API is served, so requests sent to the
+// server of another product aren't answered by it
+const ChecksPath = "/checks"
+
+// Fault makes the server fail or slow down requests matching Method and
+// PathPrefix. Empty Method or PathPrefix match every request. A zero
+// StatusCode only adds Latency. Times limits how many requests are affected,
+// zero affects all of them. With AfterHandling the request is processed
+// before failing, like a response lost after the API made the change.
+type Fault struct {
+	Method        string
+	PathPrefix    string
+	StatusCode    int
+	Latency       time.Duration
+	Times         int
+	AfterHandling bool
+}
+
+type ServerOption func(*Server)
+
+// Server is a fake Truora API served by an httptest.Server
+type Server struct {
+	*httptest.Server
+
+	apiKey string
+	now    func() time.Time
+
+	mu               sync.Mutex
+	flows            map[string]*truora.IdentityProcessFlowResponse
+	hooks            map[string]*truora.Hook
+	keys             map[string]*truora.APIKey
+	processes        map[string]*truora.ProcessResult
+	continuousChecks map[string]*truora.ContinuousCheck
+	checkCustomTypes map[string]*truora.CheckCustomType
+	outbounds        map[string]*storedOutbound
+	outboundReview   OutboundReview
+	faults           []*Fault
+}
+
+// WithAPIKey changes the key the server accepts
+func WithAPIKey(apiKey string) ServerOption {
+	return func(s *Server) {
+		s.apiKey = apiKey
+	}
+}
+
+// WithClock replaces the time source used for creation and update dates
+func WithClock(now func() time.Time) ServerOption {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// NewServer starts a fake server, it must be closed with Close
+func NewServer(opts ...ServerOption) *Server {
+	s := &Server{
+		apiKey:           APIKey,
+		now:              time.Now,
+		flows:            map[string]*truora.IdentityProcessFlowResponse{},
+		hooks:            map[string]*truora.Hook{},
+		keys:             map[string]*truora.APIKey{},
+		processes:        map[string]*truora.ProcessResult{},
+		continuousChecks: map[string]*truora.ContinuousCheck{},
+		checkCustomTypes: map[string]*truora.CheckCustomType{},
+		outbounds:        map[string]*storedOutbound{},
+	}
+
+	for _, o := range opts {
+		o(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/flows", s.handleFlows)
+	mux.HandleFunc("/v1/flows/", s.handleFlow)
+	mux.HandleFunc("/v1/hooks", s.handleHooks)
+	mux.HandleFunc("/v1/hooks/", s.handleHook)
+	mux.HandleFunc("/v1/api-keys", s.handleAPIKeys)
+	mux.HandleFunc("/v1/api-keys/", s.handleAPIKey)
+	mux.HandleFunc("/v1/processes/", s.handleProcess)
+	mux.HandleFunc("/v1/whatsapp/outbounds", s.handleOutbounds)
+	mux.HandleFunc("/v1/whatsapp/outbounds/", s.handleOutbound)
+
+	checks := http.NewServeMux()
+	checks.HandleFunc("/v1/continuous-checks", s.handleContinuousChecks)
+	checks.HandleFunc("/v1/continuous-checks/", s.handleContinuousCheck)
+	checks.HandleFunc("/v1/config", s.handleCheckCustomTypes)
+	mux.Handle(ChecksPath+"/", http.StripPrefix(ChecksPath, checks))
+
+	s.Server = httptest.NewServer(s.withFaults(s.withAPIKey(mux)))
+
+	return s
+}
+
+// Endpoints returns endpoints that point every product to the fake server
+func (s *Server) Endpoints() truora.Endpoints {
+	return truora.Endpoints{
+		Identity:    s.URL,
+		Checks:      s.URL + ChecksPath,
+		Account:     s.URL,
+		Validations: s.URL,
+		Connect:     s.URL,
+		Web:         s.URL,
+	}
+}
+
+// NewClient returns a client configured to talk to the fake server
+func (s *Server) NewClient(opts ...truora.TruoraClientOption) (*truora.TruoraClient, error) {
+	opts = append([]truora.TruoraClientOption{
+		truora.WithAPIKey(s.apiKey),
+		truora.WithEndpoints(s.Endpoints()),
+	}, opts...)
+
+	return truora.NewClient(opts...)
+}
+
+// InjectFault adds a fault, faults are checked in the order they were added
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every injected fault
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// AddFlow stores a flow as if it was created through the API and returns it.
+// Like httptest.NewServer, the helpers used by tests panic when the server
+// fails, here if the flow can't be copied.
+func (s *Server) AddFlow(flow *truora.IdentityProcessFlow) *truora.IdentityProcessFlowResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	created, err := s.createFlow(flow)
+	if err != nil {
+		panic("truoratest: " + err.Error())
+	}
+
+	return created
+}
+
+// Flow returns a copy of a stored flow
+func (s *Server) Flow(flowID string) (*truora.IdentityProcessFlowResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	flow, ok, err := s.storedFlow(flowID)
+	if err != nil {
+		panic("truoratest: " + err.Error())
+	}
+
+	return flow, ok
+}
+
+// Flows returns a copy of every stored flow sorted by flow ID
+func (s *Server) Flows() []*truora.IdentityProcessFlowResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	flows, err := s.sortedFlows()
+	if err != nil {
+		panic("truoratest: " + err.Error())
+	}
+
+	return flows
+}
+
+// Hook returns a copy of a stored hook, including the credentials the API
+// never returns
+func (s *Server) Hook(hookID string) (*truora.Hook, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hook, ok := s.hooks[hookID]
+	if !ok {
+		return nil, false
+	}
+
+	hookCopy := *hook
+
+	return &hookCopy, true
+}
+
+// PutHook stores a hook as is, replacing the one with the same hook ID
+func (s *Server) PutHook(hook *truora.Hook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hookCopy := *hook
+	s.hooks[hook.HookID] = &hookCopy
+}
+
+// APIKeys returns the names of the API keys created through the server
+func (s *Server) APIKeys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.keys))
+	for name := range s.keys {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (s *Server) withAPIKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Truora-Api-Key") != s.apiKey {
+			writeError(w, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) withFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fault := s.matchFault(r)
+		if fault != nil {
+			if fault.Latency > 0 {
+				select {
+				case <-time.After(fault.Latency):
+				case <-r.Context().Done():
+					return
+				}
+			}
+
+			if fault.StatusCode != 0 {
+				if fault.AfterHandling {
+					next.ServeHTTP(httptest.NewRecorder(), r)
+				}
+
+				if fault.StatusCode == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "1")
+				}
+
+				writeError(w, fault.StatusCode, http.StatusText(fault.StatusCode))
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) matchFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+
+		if !strings.HasPrefix(r.URL.Path, fault.PathPrefix) {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		return fault
+	}
+
+	return nil
+}
+
+func (s *Server) handleFlows(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.mu.Lock()
+		flows, err := s.sortedFlows()
+		s.mu.Unlock()
+
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		writeJSON(w, http.StatusOK, truora.ListFlowsResponse{Flows: flows})
+	case http.MethodPost:
+		flow, ok := decodeFlow(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		created, err := s.createFlow(flow)
+		s.mu.Unlock()
+
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, created)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	}
+}
+
+func (s *Server) handleFlow(w http.ResponseWriter, r *http.Request) {
+	flowID := strings.TrimPrefix(r.URL.Path, "/v1/flows/")
+	if flowID == "" || strings.Contains(flowID, "/") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.mu.Lock()
+		flow, ok, err := s.storedFlow(flowID)
+		s.mu.Unlock()
+
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if !ok {
+			writeError(w, http.StatusNotFound, "flow not found")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, flow)
+	case http.MethodPost:
+		flow, ok := decodeFlow(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		updated, found, err := s.updateFlow(flowID, flow)
+		s.mu.Unlock()
+
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if !found {
+			writeError(w, http.StatusNotFound, "flow not found")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, updated)
+	case http.MethodDelete:
+		s.mu.Lock()
+		_, found := s.flows[flowID]
+		delete(s.flows, flowID)
+		s.mu.Unlock()
+
+		if !found {
+			writeError(w, http.StatusNotFound, "flow not found")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]string{"flow_id": flowID})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	}
+}
+
+func (s *Server) handleHooks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		return
+	}
+
+	hook, ok := decodeHook(w, r)
+	if !ok {
+		return
+	}
+
+	hook.HookID = newID("HKS")
+
+	s.PutHook(hook)
+
+	writeJSON(w, http.StatusOK, publicHook(hook))
+}
+
+func (s *Server) handleHook(w http.ResponseWriter, r *http.Request) {
+	hookID := strings.TrimPrefix(r.URL.Path, "/v1/hooks/")
+	if hookID == "" || strings.Contains(hookID, "/") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	existing, found := s.Hook(hookID)
+	if !found {
+		writeError(w, http.StatusNotFound, "hook not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, publicHook(existing))
+	case http.MethodPut:
+		hook, ok := decodeHook(w, r)
+		if !ok {
+			return
+		}
+
+		hook.HookID = hookID
+
+		s.PutHook(hook)
+
+		writeJSON(w, http.StatusOK, publicHook(hook))
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.hooks, hookID)
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, map[string]string{"hook_id": hookID})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	}
+}
+
+func (s *Server) handleAPIKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	key := &truora.APIKey{
+		KeyName: r.PostForm.Get("key_name"),
+		KeyType: r.PostForm.Get("key_type"),
+		Grants:  r.PostForm["grant"],
+		FlowID:  r.PostForm.Get("flow_id"),
+	}
+
+	if key.KeyName == "" {
+		writeError(w, http.StatusBadRequest, "key_name is required")
+		return
+	}
+
+	if value := r.PostForm.Get("expires_in"); value != "" {
+		expiresIn, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || expiresIn < 1 {
+			writeError(w, http.StatusBadRequest, "expires_in must be a positive number of seconds")
+			return
+		}
+
+		expiration := s.now().UTC().Add(time.Duration(expiresIn) * time.Second)
+		key.Expiration = &expiration
+	}
+
+	if value := r.PostForm.Get("expiration"); value != "" {
+		expiration, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "expiration must be an RFC 3339 time")
+			return
+		}
+
+		expiration = expiration.UTC()
+		key.Expiration = &expiration
+	}
+
+	s.mu.Lock()
+	if _, found := s.keys[key.KeyName]; found {
+		s.mu.Unlock()
+		writeError(w, http.StatusConflict, "an api key with this name already exists")
+		return
+	}
+
+	s.keys[key.KeyName] = key
+	s.mu.Unlock()
+
+	created := *key
+	created.Key = newID("KEY")
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) handleAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyName := strings.TrimPrefix(r.URL.Path, "/v1/api-keys/")
+
+	s.mu.Lock()
+	key, found := s.keys[keyName]
+	if found && r.Method == http.MethodDelete {
+		delete(s.keys, keyName)
+	}
+	s.mu.Unlock()
+
+	if !found {
+		writeError(w, http.StatusNotFound, "api key not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, key)
+	case http.MethodDelete:
+		writeJSON(w, http.StatusOK, map[string]string{"key_name": keyName})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	}
+}
+
+func decodeHook(w http.ResponseWriter, r *http.Request) (*truora.Hook, bool) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	hook := &truora.Hook{
+		EventType:   r.PostForm.Get("event_type"),
+		EventAction: r.PostForm.Get("event_action"),
+		URL:         r.PostForm.Get("url"),
+		AuthType:    r.PostForm.Get("auth_type"),
+		Username:    r.PostForm.Get("username"),
+		Password:    r.PostForm.Get("password"),
+		Token:       r.PostForm.Get("auth_token"),
+		Status:      r.PostForm.Get("status"),
+	}
+
+	if hook.EventType == "" || hook.URL == "" {
+		writeError(w, http.StatusBadRequest, "event_type and url are required")
+		return nil, false
+	}
+
+	return hook, true
+}
+
+// publicHook drops the credentials, which the API never returns
+func publicHook(hook *truora.Hook) *truora.Hook {
+	hookCopy := *hook
+	hookCopy.Password = ""
+	hookCopy.Token = ""
+
+	return &hookCopy
+}
+
+// storedFlow must be called with s.mu held
+func (s *Server) storedFlow(flowID string) (*truora.IdentityProcessFlowResponse, bool, error) {
+	flow, ok := s.flows[flowID]
+	if !ok {
+		return nil, false, nil
+	}
+
+	flowCopy, err := truora.CopyFlowResponse(flow)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return flowCopy, true, nil
+}
+
+// createFlow must be called with s.mu held
+func (s *Server) createFlow(flow *truora.IdentityProcessFlow) (*truora.IdentityProcessFlowResponse, error) {
+	now := s.now().UTC()
+
+	created := &truora.IdentityProcessFlowResponse{
+		FlowID:                newID("IPF"),
+		ClientID:              flow.ClientID,
+		Version:               1,
+		Name:                  flow.Name,
+		Status:                "active",
+		Type:                  flow.Type,
+		CreationDate:          &now,
+		UpdateDate:            &now,
+		VersionStartDate:      &now,
+		Config:                flow.Config,
+		IdentityVerifications: assignIDs(flow.IdentityVerifications, nil),
+	}
+
+	stored, err := truora.CopyFlowResponse(created)
+	if err != nil {
+		return nil, err
+	}
+
+	s.flows[created.FlowID] = stored
+
+	return created, nil
+}
+
+// updateFlow must be called with s.mu held
+func (s *Server) updateFlow(flowID string, flow *truora.IdentityProcessFlow) (*truora.IdentityProcessFlowResponse, bool, error) {
+	existing, ok := s.flows[flowID]
+	if !ok {
+		return nil, false, nil
+	}
+
+	now := s.now().UTC()
+
+	existing.Version++
+	existing.Name = flow.Name
+	existing.Type = flow.Type
+	existing.Config = flow.Config
+	existing.UpdateDate = &now
+	existing.VersionStartDate = &now
+	existing.IdentityVerifications = assignIDs(flow.IdentityVerifications, existing.IdentityVerifications)
+
+	updated, err := truora.CopyFlowResponse(existing)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return updated, true, nil
+}
+
+// sortedFlows must be called with s.mu held
+func (s *Server) sortedFlows() ([]*truora.IdentityProcessFlowResponse, error) {
+	flows := make([]*truora.IdentityProcessFlowResponse, 0, len(s.flows))
+	for _, flow := range s.flows {
+		flowCopy, err := truora.CopyFlowResponse(flow)
+		if err != nil {
+			return nil, err
+		}
+
+		flows = append(flows, flowCopy)
+	}
+
+	sort.Slice(flows, func(i, j int) bool {
+		return flows[i].FlowID < flows[j].FlowID
+	})
+
+	return flows, nil
+}
+
+// assignIDs gives server IDs to verifications and steps that don't have one.
+// Verifications keep the ID they had in previous when their name matches.
+func assignIDs(verifications, previous []*truora.IdentityVerification) []*truora.IdentityVerification {
+	previousIDs := map[string]string{}
+	for _, verification := range previous {
+		previousIDs[verification.Name] = verification.VerificationID
+	}
+
+	for _, verification := range verifications {
+		if verification.VerificationID == "" {
+			verification.VerificationID = previousIDs[verification.Name]
+		}
+
+		if verification.VerificationID == "" {
+			verification.VerificationID = newID("IDV")
+		}
+
+		for _, step := range verification.Steps {
+			if step.StepID == "" {
+				step.StepID = newID("STP")
+			}
+		}
+	}
+
+	return verifications
+}
+
+func decodeFlow(w http.ResponseWriter, r *http.Request) (*truora.IdentityProcessFlow, bool) {
+	var flow truora.IdentityProcessFlow
+	if err := json.NewDecoder(r.Body).Decode(&flow); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	if flow.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return nil, false
+	}
+
+	return &flow, true
+}
+
+func newID(prefix string) string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return prefix + hex.EncodeToString(b)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"code":    statusCode,
+		"message": message,
+	})
+}
@@ -0,0 +1,111 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event types that can be subscribed to with the truora_webhook resource
+const (
+	EventTypeIdentityProcess = "identity_process"
+	EventTypeCheck           = "check"
+	EventTypeValidation      = "validation"
+)
+
+// EventTypes lists every event type a hook can be registered for
+var EventTypes = []string{
+	EventTypeIdentityProcess,
+	EventTypeCheck,
+	EventTypeValidation,
+}
+
+// Event is the verified payload of a webhook call. Object holds the event
+// specific data and can be decoded with ProcessEvent, CheckEvent or
+// ValidationEvent depending on EventType.
+type Event struct {
+	EventID     string          `json:"event_id,omitempty"`
+	EventType   string          `json:"event_type"`
+	EventAction string          `json:"event_action"`
+	ClientID    string          `json:"client_id,omitempty"`
+	IssuedAt    int64           `json:"iat,omitempty"`
+	ExpiresAt   int64           `json:"exp,omitempty"`
+	Object      json.RawMessage `json:"object"`
+}
+
+// Process contains the identity process data sent on identity_process events
+type Process struct {
+	ProcessID      string     `json:"process_id"`
+	FlowID         string     `json:"flow_id"`
+	ClientID       string     `json:"client_id,omitempty"`
+	AccountID      string     `json:"account_id,omitempty"`
+	Status         string     `json:"status"`
+	FailureStatus  string     `json:"failure_status,omitempty"`
+	DeclinedReason string     `json:"declined_reason,omitempty"`
+	CreationDate   *time.Time `json:"creation_date,omitempty"`
+	UpdateDate     *time.Time `json:"update_date,omitempty"`
+}
+
+// Check contains the background check data sent on check events
+type Check struct {
+	CheckID      string     `json:"check_id"`
+	Country      string     `json:"country"`
+	Type         string     `json:"type"`
+	NationalID   string     `json:"national_id,omitempty"`
+	Status       string     `json:"status"`
+	Score        float64    `json:"score"`
+	CreationDate *time.Time `json:"creation_date,omitempty"`
+	UpdateDate   *time.Time `json:"update_date,omitempty"`
+}
+
+// Validation contains the validation data sent on validation events
+type Validation struct {
+	ValidationID     string     `json:"validation_id"`
+	Type             string     `json:"type"`
+	AccountID        string     `json:"account_id,omitempty"`
+	ValidationStatus string     `json:"validation_status"`
+	FailureStatus    string     `json:"failure_status,omitempty"`
+	CreationDate     *time.Time `json:"creation_date,omitempty"`
+}
+
+// ProcessEvent decodes the event object of an identity_process event
+func (e *Event) ProcessEvent() (*Process, error) {
+	var process Process
+	if err := e.decodeObject(EventTypeIdentityProcess, &process); err != nil {
+		return nil, err
+	}
+
+	return &process, nil
+}
+
+// CheckEvent decodes the event object of a check event
+func (e *Event) CheckEvent() (*Check, error) {
+	var check Check
+	if err := e.decodeObject(EventTypeCheck, &check); err != nil {
+		return nil, err
+	}
+
+	return &check, nil
+}
+
+// ValidationEvent decodes the event object of a validation event
+func (e *Event) ValidationEvent() (*Validation, error) {
+	var validation Validation
+	if err := e.decodeObject(EventTypeValidation, &validation); err != nil {
+		return nil, err
+	}
+
+	return &validation, nil
+}
+
+func (e *Event) decodeObject(eventType string, v interface{}) error {
+	if e.EventType != eventType {
+		return fmt.Errorf("%w: expected %s, got %s", ErrUnexpectedEventType, eventType, e.EventType)
+	}
+
+	if len(e.Object) == 0 {
+		return fmt.Errorf("%s event has no object", e.EventType)
+	}
+
+	return json.Unmarshal(e.Object, v)
+}
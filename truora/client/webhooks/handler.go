@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// maxPayloadSize limits how much of the request body is read
+const maxPayloadSize = 1 << 20
+
+// HandlerFunc processes a verified webhook event
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// Handler adapts a HandlerFunc to an http.Handler. Malformed payloads are
+// answered with 400, payloads with an invalid signature or expired with 401
+// and errors returned by handle with 500.
+func Handler(verifier *Verifier, handle HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		payload, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		event, err := verifier.Verify(payload)
+		if errors.Is(err, ErrMalformedPayload) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		if err := handle(r.Context(), event); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
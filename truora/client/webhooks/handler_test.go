@@ -0,0 +1,105 @@
+package webhooks_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-truora/truora/client/webhooks"
+)
+
+func TestHandler(t *testing.T) {
+	const header = `{"alg":"HS256","typ":"JWT"}`
+
+	cases := []struct {
+		name      string
+		method    string
+		payload   string
+		handleErr error
+		status    int
+		handled   bool
+	}{
+		{
+			name:    "good signature",
+			method:  http.MethodPost,
+			payload: signPayload(testSecret, header, `{"event_type":"identity_process","event_action":"succeeded","exp":1700000060}`),
+			status:  http.StatusOK,
+			handled: true,
+		},
+		{
+			name:    "bad signature",
+			method:  http.MethodPost,
+			payload: signPayload([]byte("other-secret"), header, `{"event_type":"identity_process","exp":1700000060}`),
+			status:  http.StatusUnauthorized,
+		},
+		{
+			name:    "expired",
+			method:  http.MethodPost,
+			payload: signPayload(testSecret, header, `{"event_type":"identity_process","exp":1699999000}`),
+			status:  http.StatusUnauthorized,
+		},
+		{
+			name:    "malformed body",
+			method:  http.MethodPost,
+			payload: "not a token",
+			status:  http.StatusBadRequest,
+		},
+		{
+			name:      "handler error",
+			method:    http.MethodPost,
+			payload:   signPayload(testSecret, header, `{"event_type":"identity_process","exp":1700000060}`),
+			handleErr: errors.New("storage unavailable"),
+			status:    http.StatusInternalServerError,
+			handled:   true,
+		},
+		{
+			name:   "GET",
+			method: http.MethodGet,
+			status: http.StatusMethodNotAllowed,
+		},
+	}
+
+	verifier, err := webhooks.NewVerifier(testSecret, webhooks.WithClock(func() time.Time { return testNow }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var handled *webhooks.Event
+
+			server := httptest.NewServer(webhooks.Handler(verifier, func(_ context.Context, event *webhooks.Event) error {
+				handled = event
+				return tc.handleErr
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequest(tc.method, server.URL, strings.NewReader(tc.payload))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := server.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, resp.StatusCode)
+			}
+
+			if (handled != nil) != tc.handled {
+				t.Fatalf("expected handled to be %t, got %v", tc.handled, handled)
+			}
+
+			if tc.handled && handled.EventType != webhooks.EventTypeIdentityProcess {
+				t.Fatalf("expected an %s event, got %q", webhooks.EventTypeIdentityProcess, handled.EventType)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformedPayload      = fmt.Errorf("malformed webhook payload")
+	ErrUnsupportedAlgorithm  = fmt.Errorf("unsupported webhook signing algorithm")
+	ErrInvalidSignature      = fmt.Errorf("invalid webhook signature")
+	ErrExpiredPayload        = fmt.Errorf("webhook payload expired")
+	ErrMissingExpiration     = fmt.Errorf("webhook payload has neither exp nor iat")
+	ErrUnexpectedEventType   = fmt.Errorf("unexpected event type")
+	ErrSigningSecretRequired = fmt.Errorf("webhook signing secret not provided")
+)
+
+// DefaultMaxAge is how long after iat a payload is accepted unless WithMaxAge
+// is used
+const DefaultMaxAge = time.Hour
+
+type VerifierOption func(*Verifier)
+
+// Verifier checks that webhook payloads are JWTs signed with the hook secret
+type Verifier struct {
+	secret []byte
+	leeway time.Duration
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// WithLeeway allows payloads that expired less than leeway ago, to account
+// for clock skew between Truora and the receiving service
+func WithLeeway(leeway time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.leeway = leeway
+	}
+}
+
+// WithMaxAge changes how long after iat a payload is accepted, so signed
+// payloads without exp can't be replayed forever
+func WithMaxAge(maxAge time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.maxAge = maxAge
+	}
+}
+
+// WithClock replaces the time source used to check expiration
+func WithClock(now func() time.Time) VerifierOption {
+	return func(v *Verifier) {
+		v.now = now
+	}
+}
+
+func NewVerifier(secret []byte, opts ...VerifierOption) (*Verifier, error) {
+	verifier := &Verifier{
+		secret: secret,
+		maxAge: DefaultMaxAge,
+		now:    time.Now,
+	}
+
+	for _, o := range opts {
+		o(verifier)
+	}
+
+	if len(verifier.secret) == 0 {
+		return nil, ErrSigningSecretRequired
+	}
+
+	return verifier, nil
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+}
+
+// Verify checks the signature and expiration of payload and decodes its claims.
+// Payloads must have an exp or an iat claim: exp is checked when present and
+// iat must be within the max age.
+func (v *Verifier) Verify(payload []byte) (*Event, error) {
+	parts := strings.Split(strings.TrimSpace(string(payload)), ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedPayload
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedPayload, err)
+	}
+
+	var header jwtHeader
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedPayload, err)
+	}
+
+	if header.Algorithm != "HS256" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedPayload, err)
+	}
+
+	mac := hmac.New(sha256.New, v.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, ErrInvalidSignature
+	}
+
+	claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedPayload, err)
+	}
+
+	var event Event
+	if err := json.Unmarshal(claims, &event); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedPayload, err)
+	}
+
+	if event.ExpiresAt == 0 && event.IssuedAt == 0 {
+		return nil, ErrMissingExpiration
+	}
+
+	now := v.now()
+
+	if event.ExpiresAt != 0 && now.After(time.Unix(event.ExpiresAt, 0).Add(v.leeway)) {
+		return nil, ErrExpiredPayload
+	}
+
+	if event.IssuedAt != 0 && now.After(time.Unix(event.IssuedAt, 0).Add(v.maxAge+v.leeway)) {
+		return nil, ErrExpiredPayload
+	}
+
+	return &event, nil
+}
@@ -0,0 +1,161 @@
+package webhooks_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"terraform-provider-truora/truora/client/webhooks"
+)
+
+var (
+	testSecret = []byte("hook-secret")
+	testNow    = time.Unix(1700000000, 0)
+)
+
+func encodeSegment(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// signPayload builds a JWT with the given header and claims signed with secret
+func signPayload(secret []byte, header, claims string) string {
+	signed := encodeSegment(header) + "." + encodeSegment(claims)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifierVerify(t *testing.T) {
+	const header = `{"alg":"HS256","typ":"JWT"}`
+
+	cases := []struct {
+		name     string
+		payload  string
+		expected error
+	}{
+		{
+			name:    "good signature",
+			payload: signPayload(testSecret, header, `{"event_type":"identity_process","event_action":"succeeded","exp":1700000060}`),
+		},
+		{
+			name:     "bad signature",
+			payload:  signPayload([]byte("other-secret"), header, `{"event_type":"identity_process","exp":1700000060}`),
+			expected: webhooks.ErrInvalidSignature,
+		},
+		{
+			name:     "HS512",
+			payload:  signPayload(testSecret, `{"alg":"HS512"}`, `{"exp":1700000060}`),
+			expected: webhooks.ErrUnsupportedAlgorithm,
+		},
+		{
+			name:     "alg none",
+			payload:  encodeSegment(`{"alg":"none"}`) + "." + encodeSegment(`{"exp":1700000060}`) + ".",
+			expected: webhooks.ErrUnsupportedAlgorithm,
+		},
+		{
+			name:     "two segments",
+			payload:  encodeSegment(header) + "." + encodeSegment(`{"exp":1700000060}`),
+			expected: webhooks.ErrMalformedPayload,
+		},
+		{
+			name:     "header isn't base64",
+			payload:  "%%%." + encodeSegment(`{"exp":1700000060}`) + ".sig",
+			expected: webhooks.ErrMalformedPayload,
+		},
+		{
+			name:     "claims aren't JSON",
+			payload:  signPayload(testSecret, header, `not json`),
+			expected: webhooks.ErrMalformedPayload,
+		},
+		{
+			name:    "expired inside the leeway",
+			payload: signPayload(testSecret, header, `{"exp":1699999990}`),
+		},
+		{
+			name:     "expired outside the leeway",
+			payload:  signPayload(testSecret, header, `{"exp":1699999900}`),
+			expected: webhooks.ErrExpiredPayload,
+		},
+		{
+			name:     "no exp nor iat",
+			payload:  signPayload(testSecret, header, `{"event_type":"identity_process"}`),
+			expected: webhooks.ErrMissingExpiration,
+		},
+		{
+			name:    "recent iat without exp",
+			payload: signPayload(testSecret, header, `{"iat":1699999000}`),
+		},
+		{
+			name:     "iat older than the max age",
+			payload:  signPayload(testSecret, header, `{"iat":1699990000}`),
+			expected: webhooks.ErrExpiredPayload,
+		},
+	}
+
+	verifier, err := webhooks.NewVerifier(testSecret,
+		webhooks.WithLeeway(30*time.Second),
+		webhooks.WithMaxAge(time.Hour),
+		webhooks.WithClock(func() time.Time { return testNow }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			event, err := verifier.Verify([]byte(tc.payload))
+			if tc.expected == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if event == nil {
+					t.Fatal("expected an event")
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestEventUnexpectedEventType(t *testing.T) {
+	verifier, err := webhooks.NewVerifier(testSecret, webhooks.WithClock(func() time.Time { return testNow }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event, err := verifier.Verify([]byte(signPayload(testSecret, `{"alg":"HS256"}`,
+		`{"event_type":"check","exp":1700000060,"object":{"check_id":"CHK1"}}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := event.ProcessEvent(); !errors.Is(err, webhooks.ErrUnexpectedEventType) {
+		t.Fatalf("expected %v, got %v", webhooks.ErrUnexpectedEventType, err)
+	}
+
+	check, err := event.CheckEvent()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.CheckID != "CHK1" {
+		t.Fatalf("expected check CHK1, got %s", check.CheckID)
+	}
+}
+
+func TestNewVerifierRequiresSecret(t *testing.T) {
+	if _, err := webhooks.NewVerifier(nil); !errors.Is(err, webhooks.ErrSigningSecretRequired) {
+		t.Fatalf("expected %v, got %v", webhooks.ErrSigningSecretRequired, err)
+	}
+}
@@ -0,0 +1,89 @@
+package truora
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+// dataSourceAccount decodes the configured API key, it doesn't call the API
+func dataSourceAccount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAccountRead,
+		Schema: map[string]*schema.Schema{
+			"client_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"key_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"key_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"grants": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"expiration": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*truora.TruoraClient)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	claims, err := c.APIKeyClaims()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("client_id", claims.ClientID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("key_name", claims.KeyName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("key_type", claims.KeyType); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("username", claims.Username); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("grants", claims.Grants); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if claims.ExpiresAt != nil {
+		if err = d.Set("expiration", claims.ExpiresAt.Format(time.RFC3339)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(claims.ClientID)
+
+	return diags
+}
@@ -141,7 +141,7 @@ func dataSourceFlowRead(ctx context.Context, d *schema.ResourceData, m interface
 
 	flowID := d.Get("flow_id").(string)
 
-	flow, err := c.GetFlow(flowID)
+	flow, err := c.GetFlow(ctx, flowID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -150,11 +150,11 @@ func dataSourceFlowRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
-	if err = d.Set("creation_date", flow.CreationDate.Format("2006-01-02T15:04:05.000Z")); err != nil {
+	if err = d.Set("creation_date", formatOptionalTime(flow.CreationDate)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err = d.Set("update_date", flow.UpdateDate.Format("2006-01-02T15:04:05.000Z")); err != nil {
+	if err = d.Set("update_date", formatOptionalTime(flow.UpdateDate)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -81,16 +81,22 @@ func requestVerificationSchema() map[string]*schema.Schema {
 	}
 }
 
+const (
+	defaultFlowType = "permanent"
+	// defaultFlowLang was the default of config.lang, it now only applies
+	// when no layer of the document sets lang
+	defaultFlowLang = "es"
+)
+
 func requestFlowSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"type": {
 			Type:     schema.TypeString,
 			Optional: true,
-			Default:  "permanent",
 		},
 		"name": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
 		},
 		"config": {
 			Type:       schema.TypeList,
@@ -100,9 +106,9 @@ func requestFlowSchema() map[string]*schema.Schema {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"lang": {
-						Type:     schema.TypeString,
-						Optional: true,
-						Default:  "es",
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: validateFlowLanguage,
 					},
 					"enable_desktop_flow": {
 						Type:     schema.TypeBool,
@@ -112,31 +118,48 @@ func requestFlowSchema() map[string]*schema.Schema {
 						Type:     schema.TypeBool,
 						Optional: true,
 					},
+					"enable_postponed_web_process": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+					"ignore_initial_message": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
 					"enable_follow_up": {
 						Type:     schema.TypeBool,
 						Optional: true,
 					},
 					"follow_up_delay": {
-						Type:     schema.TypeInt,
-						Optional: true,
+						Type:             schema.TypeInt,
+						Optional:         true,
+						ValidateDiagFunc: validateFollowUpDelay,
 						RequiredWith: []string{
-							"config.enable_follow_up",
+							"config.0.enable_follow_up",
 						},
 					},
 					"follow_up_message": {
 						Type:     schema.TypeString,
 						Optional: true,
 						RequiredWith: []string{
-							"config.enable_follow_up",
+							"config.0.enable_follow_up",
 						},
 					},
 					"start_business_hours": {
-						Type:     schema.TypeString,
-						Optional: true,
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: validateBusinessHoursSetting,
+						RequiredWith: []string{
+							"config.0.end_business_hours",
+						},
 					},
 					"end_business_hours": {
-						Type:     schema.TypeString,
-						Optional: true,
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: validateBusinessHoursSetting,
+						RequiredWith: []string{
+							"config.0.start_business_hours",
+						},
 					},
 					"messages": {
 						Type:       schema.TypeList,
@@ -175,8 +198,9 @@ func requestFlowSchema() map[string]*schema.Schema {
 												Required: true,
 											},
 											"status": {
-												Type:     schema.TypeString,
-												Required: true,
+												Type:             schema.TypeString,
+												Required:         true,
+												ValidateDiagFunc: validateCustomMessageStatus,
 											},
 										},
 									},
@@ -187,12 +211,30 @@ func requestFlowSchema() map[string]*schema.Schema {
 				},
 			},
 		},
+		"verification_json": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validateVerificationDocumentJSON,
+			},
+		},
 		"verification": {
 			Type:     schema.TypeList,
-			Required: true,
-			MinItems: 1,
+			Optional: true,
 			Elem: &schema.Resource{
-				Schema: requestVerificationSchema(),
+				Schema: mergeSchemaMaps(
+					requestVerificationSchema(),
+					map[string]*schema.Schema{
+						// verification_json and verification are separate
+						// arguments, position lets blocks go between fragments
+						"position": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							ValidateDiagFunc: validateVerificationPosition,
+						},
+					},
+				),
 			},
 		},
 	}
@@ -207,6 +249,24 @@ func dataSourceFlowDocument() *schema.Resource {
 					Type:     schema.TypeString,
 					Computed: true,
 				},
+				"canonical_json": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"sha256": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"source_json": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateFlowDocumentJSON,
+				},
+				"override_json": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateFlowDocumentJSON,
+				},
 			},
 			requestFlowSchema(),
 		),
@@ -217,16 +277,55 @@ func dataFlowDocumentRead(ctx context.Context, d *schema.ResourceData, m interfa
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	flow := flowFromResourceData(d)
+	document, err := flowDocumentFromConfig(d.GetRawConfig())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	document, err = mergeFlowDocument(
+		document,
+		d.Get("source_json").(string),
+		d.Get("override_json").(string),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if config, ok := document["config"].(map[string]interface{}); ok {
+		if diags := validateFlowConfig(config); diags.HasError() {
+			return diags
+		}
+	}
+
+	if isEmptyJSONValue(document["type"]) {
+		document["type"] = defaultFlowType
+	}
+
+	if name, _ := document["name"].(string); name == "" {
+		return diag.Errorf("flow name must be set in the name argument, source_json or override_json")
+	}
 
-	flowMarshal, err := json.Marshal(flow)
+	if verifications, _ := document["identity_verifications"].([]interface{}); len(verifications) == 0 {
+		return diag.Errorf("flow must have at least one verification block, verification_json fragment or identity_verifications entry in source_json or override_json")
+	}
+
+	flowMarshal, err := json.Marshal(document)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	canonical, err := canonicalJSON(flowMarshal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	checksum := sha256Hex(canonical)
+
 	d.Set("json", string(flowMarshal))
+	d.Set("canonical_json", string(canonical))
+	d.Set("sha256", checksum)
 
-	d.SetId(flow.Name)
+	d.SetId(checksum)
 
 	return diags
 }
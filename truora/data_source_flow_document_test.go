@@ -0,0 +1,370 @@
+package truora
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	hashicorpctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/export"
+)
+
+func TestAccDataSourceFlowDocument_basic(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckFlowDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceFlowDocumentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.truora_flow_document.test", "sha256"),
+					resource.TestCheckResourceAttrPair("data.truora_flow_document.test", "id", "data.truora_flow_document.test", "sha256"),
+					resource.TestCheckResourceAttr("truora_flow.test", "name", "document flow"),
+					resource.TestCheckResourceAttr("truora_flow.test", "version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceFlowDocument_merge(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		PreCheck:                 func() { testAccFakeServer(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceFlowDocumentMergeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.truora_flow_document.test",
+						"json",
+						`{"config":{"enable_desktop_flow":false,"lang":"en","time_to_live":3600},"identity_verifications":[{"name":"email_verification"},{"name":"phone_verification"}],"name":"market flow","type":"permanent"}`,
+					),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceFlowDocumentConfig = `
+data "truora_flow_document" "test" {
+  name = "document flow"
+
+  config {
+    lang                = "en"
+    enable_desktop_flow = true
+  }
+
+  verification {
+    name = "email_verification"
+  }
+}
+
+resource "truora_flow" "test" {
+  document = data.truora_flow_document.test.json
+}
+`
+
+const testAccDataSourceFlowDocumentMergeConfig = `
+data "truora_flow_document" "test" {
+  source_json = jsonencode({
+    name = "base flow"
+    config = {
+      lang                = "es"
+      enable_desktop_flow = true
+      time_to_live        = 3600
+    }
+    identity_verifications = [
+      { name = "email_verification" },
+    ]
+  })
+
+  name = "market flow"
+
+  config {
+    enable_desktop_flow = false
+  }
+
+  verification {
+    name = "phone_verification"
+  }
+
+  override_json = jsonencode({
+    config = {
+      lang = "en"
+    }
+  })
+}
+`
+
+// TestFlowDocumentExportRoundTrip checks that a flow exported as a
+// truora_flow_document data source builds the document of the flow again
+func TestFlowDocumentExportRoundTrip(t *testing.T) {
+	bogota := time.FixedZone("COT", -5*60*60)
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, bogota)
+	end := time.Date(2024, 1, 1, 18, 30, 0, 0, bogota)
+
+	cases := []struct {
+		name string
+		flow *truora.IdentityProcessFlowResponse
+	}{
+		{
+			name: "blocks",
+			flow: &truora.IdentityProcessFlowResponse{
+				FlowID: "IPF1",
+				Name:   "Onboarding flow",
+				Type:   "permanent",
+				Config: &truora.IdentityFlowConfig{
+					Lang:                      "en",
+					EnableDesktopFlow:         true,
+					ContinueFlowInNewDevice:   true,
+					EnablePostponedWebProcess: true,
+					IgnoreInitialMessage:      true,
+					EnableFollowUp:            true,
+					FollowUpDelay:             3600000,
+					FollowUpMessage:           "Come back",
+					StartBusinessHours:        &start,
+					EndBusinessHours:          &end,
+					Messages: &truora.Messages{
+						SuccessMessage: "Done",
+						FailureMessage: "Failed",
+						CustomMessages: []*truora.CustomFinalMessage{{Message: "Almost there", Status: "pending"}},
+					},
+				},
+				IdentityVerifications: []*truora.IdentityVerification{
+					{
+						VerificationID: "IDV1",
+						Name:           "phone_verification",
+						Config:         map[string]interface{}{"country": "CO"},
+						Logic:          []string{"document_validation.status == success"},
+						Steps: []*truora.Step{
+							{
+								StepID: "STP1",
+								Type:   "phone-verification",
+								Title:  "Phone",
+								ExpectedInputs: []*truora.Input{
+									{
+										Type: "single-choice",
+										Name: "carrier",
+										ResponseOptions: []*truora.ResponseOption{
+											{Value: "claro", Alias: "Claro"},
+											{Value: "movistar"},
+										},
+									},
+								},
+							},
+						},
+					},
+					{VerificationID: "IDV2", Name: "email_verification"},
+				},
+			},
+		},
+		{
+			// blocks and verification_json are mixed, in the original order
+			name: "verification JSON",
+			flow: &truora.IdentityProcessFlowResponse{
+				FlowID: "IPF2",
+				Name:   "Documents",
+				Type:   "temporary",
+				Config: &truora.IdentityFlowConfig{Lang: "es"},
+				IdentityVerifications: []*truora.IdentityVerification{
+					{VerificationID: "IDV1", Name: "document_validation", Config: map[string]interface{}{"retries": 3, "strict": true}},
+					{VerificationID: "IDV2", Name: "phone_verification", Config: map[string]interface{}{"country": "CO"}},
+					{VerificationID: "IDV3", Name: "face_recognition", Config: map[string]interface{}{"threshold": 0.8}},
+					{VerificationID: "IDV4", Name: "email_verification"},
+				},
+			},
+		},
+		{
+			name: "follow up disabled",
+			flow: &truora.IdentityProcessFlowResponse{
+				FlowID: "IPF3",
+				Name:   "Reminders",
+				Type:   "permanent",
+				Config: &truora.IdentityFlowConfig{
+					Lang:            "es",
+					FollowUpDelay:   3600000,
+					FollowUpMessage: "Come back",
+				},
+				IdentityVerifications: []*truora.IdentityVerification{
+					{VerificationID: "IDV1", Name: "email_verification"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, err := export.RenderFlow(tc.flow, "exported", export.FormatHCL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := decodeFlowDocumentConfig(t, rendered)
+			validateFlowDocumentConfig(t, config)
+
+			document := flowDocumentFromTestConfig(t, config)
+
+			merged, err := mergeFlowDocument(document, "", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// unknown keys fail the decoding, so attributes the document
+			// doesn't map back to the flow are caught too
+			decoder := json.NewDecoder(bytes.NewReader(mustMarshal(t, merged)))
+			decoder.DisallowUnknownFields()
+
+			var actual truora.IdentityProcessFlow
+			if err := decoder.Decode(&actual); err != nil {
+				t.Fatalf("%s\n%s", err, rendered)
+			}
+
+			expected := truora.IdentityProcessFlow{
+				Name:                  tc.flow.Name,
+				Type:                  tc.flow.Type,
+				Config:                tc.flow.Config,
+				IdentityVerifications: withoutServerIDs(tc.flow.IdentityVerifications),
+			}
+
+			if expectedJSON, actualJSON := mustMarshal(t, expected), mustMarshal(t, actual); !bytes.Equal(expectedJSON, actualJSON) {
+				t.Fatalf("expected %s, got %s\n%s", expectedJSON, actualJSON, rendered)
+			}
+		})
+	}
+}
+
+// decodeFlowDocumentConfig returns the config of the truora_flow_document in
+// src as JSON, the way Terraform would decode it
+func decodeFlowDocumentConfig(t *testing.T, src []byte) string {
+	t.Helper()
+
+	file, diags := hclparse.NewParser().ParseHCL(src, "exported.tf")
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "data", LabelNames: []string{"type", "name"}}},
+	})
+	if diags.HasErrors() || len(content.Blocks) != 1 {
+		t.Fatalf("expected a single data block, got %d: %s", len(content.Blocks), diags)
+	}
+
+	ctx := &hcl.EvalContext{
+		Functions: map[string]function.Function{"jsonencode": stdlib.JSONEncodeFunc},
+	}
+
+	value, diags := hcldec.Decode(content.Blocks[0].Body, resourceDecoderSpec(dataSourceFlowDocument()), ctx)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	config, err := ctyjson.Marshal(value, value.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(config)
+}
+
+// validateFlowDocumentConfig runs the validation Terraform runs on a
+// configuration, like RequiredWith, on config written as JSON
+func validateFlowDocumentConfig(t *testing.T, config string) {
+	t.Helper()
+
+	dataSource := dataSourceFlowDocument()
+
+	value, err := hashicorpctyjson.Unmarshal([]byte(config), dataSource.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("error decoding config: %s", err)
+	}
+
+	diags := dataSource.Validate(terraform.NewResourceConfigShimmed(value, dataSource.CoreConfigSchema()))
+	if diags.HasError() {
+		t.Fatalf("invalid config %s: %v", config, diags)
+	}
+}
+
+// resourceDecoderSpec builds the HCL decoder spec of the configurable
+// attributes and blocks of r
+func resourceDecoderSpec(r *schema.Resource) hcldec.Spec {
+	spec := hcldec.ObjectSpec{}
+
+	for name, s := range r.Schema {
+		if !s.Required && !s.Optional {
+			continue
+		}
+
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			spec[name] = &hcldec.BlockListSpec{
+				TypeName: name,
+				Nested:   resourceDecoderSpec(elem),
+				MinItems: s.MinItems,
+				MaxItems: s.MaxItems,
+			}
+
+			continue
+		}
+
+		spec[name] = &hcldec.AttrSpec{Name: name, Type: schemaType(s), Required: s.Required}
+	}
+
+	return spec
+}
+
+func schemaType(s *schema.Schema) cty.Type {
+	switch s.Type {
+	case schema.TypeBool:
+		return cty.Bool
+	case schema.TypeInt, schema.TypeFloat:
+		return cty.Number
+	case schema.TypeList:
+		return cty.List(schemaType(s.Elem.(*schema.Schema)))
+	case schema.TypeMap:
+		// maps without Elem hold strings
+		if elem, ok := s.Elem.(*schema.Schema); ok {
+			return cty.Map(schemaType(elem))
+		}
+
+		return cty.Map(cty.String)
+	default:
+		return cty.String
+	}
+}
+
+// withoutServerIDs copies verifications without the IDs assigned by the API
+func withoutServerIDs(verifications []*truora.IdentityVerification) []*truora.IdentityVerification {
+	copies := make([]*truora.IdentityVerification, 0, len(verifications))
+
+	for _, verification := range verifications {
+		verificationCopy := *verification
+		verificationCopy.VerificationID = ""
+		verificationCopy.Steps = nil
+
+		for _, step := range verification.Steps {
+			stepCopy := *step
+			stepCopy.StepID = ""
+			verificationCopy.Steps = append(verificationCopy.Steps, &stepCopy)
+		}
+
+		copies = append(copies, &verificationCopy)
+	}
+
+	return copies
+}
@@ -0,0 +1,53 @@
+package truora
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+func TestAccDataSourceFlow_basic(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	flow := server.AddFlow(&truora.IdentityProcessFlow{
+		Name: "existing flow",
+		Type: "permanent",
+		Config: &truora.IdentityFlowConfig{
+			Lang:              "en",
+			EnableDesktopFlow: true,
+		},
+		IdentityVerifications: []*truora.IdentityVerification{
+			{
+				Name: "document_validation",
+				Steps: []*truora.Step{
+					{Type: "document-validation"},
+				},
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "truora_flow" "test" {
+  flow_id = %q
+}
+`, flow.FlowID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.truora_flow.test", "name", "existing flow"),
+					resource.TestCheckResourceAttr("data.truora_flow.test", "type", "permanent"),
+					resource.TestCheckResourceAttr("data.truora_flow.test", "version", "1"),
+					resource.TestCheckResourceAttr("data.truora_flow.test", "config.0.lang", "en"),
+					resource.TestCheckResourceAttr("data.truora_flow.test", "identity_verifications.0.name", "document_validation"),
+					resource.TestCheckResourceAttrSet("data.truora_flow.test", "identity_verifications.0.verification_id"),
+					resource.TestCheckResourceAttrSet("data.truora_flow.test", "identity_verifications.0.steps.0.step_id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,142 @@
+package truora
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+func dataSourceProcess() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceProcessRead,
+		Schema: map[string]*schema.Schema{
+			"process_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"flow_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"failure_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"declined_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"creation_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"update_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"validations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"validation_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"validation_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"failure_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"creation_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"update_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceProcessRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*truora.TruoraClient)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	processID := d.Get("process_id").(string)
+
+	process, err := c.GetProcessResult(ctx, processID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("flow_id", process.FlowID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("status", process.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("failure_status", process.FailureStatus); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("declined_reason", process.DeclinedReason); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("creation_date", formatOptionalTime(process.CreationDate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("update_date", formatOptionalTime(process.UpdateDate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("validations", mapProcessValidations(process.Validations)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(process.ProcessID)
+
+	return diags
+}
+
+func mapProcessValidations(validations []*truora.ProcessValidation) []interface{} {
+	mapValidations := make([]interface{}, len(validations))
+
+	for i, validation := range validations {
+		mapValidation := make(map[string]interface{})
+		mapValidation["validation_id"] = validation.ValidationID
+		mapValidation["type"] = validation.Type
+		mapValidation["validation_status"] = validation.ValidationStatus
+		mapValidation["failure_status"] = validation.FailureStatus
+		mapValidation["creation_date"] = formatOptionalTime(validation.CreationDate)
+		mapValidation["update_date"] = formatOptionalTime(validation.UpdateDate)
+
+		mapValidations[i] = mapValidation
+	}
+
+	return mapValidations
+}
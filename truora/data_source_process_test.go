@@ -0,0 +1,81 @@
+package truora
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+func TestAccDataSourceProcess_basic(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	// dates come back in the zone of the API, they are stored in UTC
+	bogota := time.FixedZone("COT", -5*60*60)
+	created := time.Date(2024, 3, 1, 21, 30, 0, 0, bogota)
+	updated := created.Add(90 * time.Second)
+
+	server.PutProcess(&truora.ProcessResult{
+		ProcessID:    "IDP123",
+		FlowID:       "IPF123",
+		Status:       "failure",
+		CreationDate: &created,
+		UpdateDate:   &updated,
+		Validations: []*truora.ProcessValidation{
+			{
+				ValidationID:     "VLD1",
+				Type:             "document-validation",
+				ValidationStatus: "failure",
+				FailureStatus:    "expired_document",
+				CreationDate:     &created,
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceProcessConfig("IDP123"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.truora_process.test", "id", "IDP123"),
+					resource.TestCheckResourceAttr("data.truora_process.test", "flow_id", "IPF123"),
+					resource.TestCheckResourceAttr("data.truora_process.test", "status", "failure"),
+					resource.TestCheckResourceAttr("data.truora_process.test", "creation_date", "2024-03-02T02:30:00.000Z"),
+					resource.TestCheckResourceAttr("data.truora_process.test", "update_date", "2024-03-02T02:31:30.000Z"),
+					resource.TestCheckResourceAttr("data.truora_process.test", "validations.#", "1"),
+					resource.TestCheckResourceAttr("data.truora_process.test", "validations.0.type", "document-validation"),
+					resource.TestCheckResourceAttr("data.truora_process.test", "validations.0.failure_status", "expired_document"),
+					resource.TestCheckResourceAttr("data.truora_process.test", "validations.0.creation_date", "2024-03-02T02:30:00.000Z"),
+					resource.TestCheckResourceAttr("data.truora_process.test", "validations.0.update_date", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceProcess_notFound(t *testing.T) {
+	testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDataSourceProcessConfig("IDPMISSING"),
+				ExpectError: regexp.MustCompile("resource not found"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceProcessConfig(processID string) string {
+	return fmt.Sprintf(`
+data "truora_process" "test" {
+  process_id = %q
+}
+`, processID)
+}
@@ -0,0 +1,73 @@
+package truora
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+func dataSourceVerificationDocument() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataVerificationDocumentRead,
+		Schema: mergeSchemaMaps(
+			map[string]*schema.Schema{
+				"json": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+			requestVerificationSchema(),
+		),
+	}
+}
+
+func dataVerificationDocumentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	verification := verificationFromResourceData(d)
+
+	verificationMarshal, err := json.Marshal(verification)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	canonical, err := canonicalJSON(verificationMarshal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("json", string(verificationMarshal))
+
+	d.SetId(sha256Hex(canonical))
+
+	return diags
+}
+
+func validateVerificationDocumentJSON(v interface{}, path cty.Path) diag.Diagnostics {
+	var verification truora.IdentityVerification
+
+	err := json.Unmarshal([]byte(v.(string)), &verification)
+	if err == nil && verification.Name == "" {
+		err = fmt.Errorf("verification document must have a name")
+	}
+
+	if err != nil {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid verification document",
+				Detail:        err.Error(),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
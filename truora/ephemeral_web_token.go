@@ -0,0 +1,179 @@
+package truora
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure = &webTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &webTokenEphemeralResource{}
+)
+
+const (
+	webTokenTypeName = "truora_web_token"
+
+	// defaultWebTokenExpiresIn keeps tokens short lived when expires_in isn't
+	// set, they are also deleted when Terraform closes the resource
+	defaultWebTokenExpiresIn = 15 * 60
+
+	webTokenKeyNamePrivateKey = "key_name"
+)
+
+// webTokenEphemeralResource mints a web integration token for a flow without
+// writing it to the plan or state. The API key behind the token is deleted
+// when Terraform is done with it, truora_flow_link keeps one as an output.
+type webTokenEphemeralResource struct {
+	client *truora.TruoraClient
+}
+
+type webTokenModel struct {
+	FlowID      types.String `tfsdk:"flow_id"`
+	Country     types.String `tfsdk:"country"`
+	RedirectURL types.String `tfsdk:"redirect_url"`
+	ExpiresIn   types.Int64  `tfsdk:"expires_in"`
+	Token       types.String `tfsdk:"token"`
+	ProcessURL  types.String `tfsdk:"process_url"`
+}
+
+func newWebTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &webTokenEphemeralResource{}
+}
+
+func (r *webTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_web_token"
+}
+
+func (r *webTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"flow_id": schema.StringAttribute{
+				Required: true,
+			},
+			"country": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"redirect_url": schema.StringAttribute{
+				Optional: true,
+			},
+			"expires_in": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: fmt.Sprintf("Lifetime of the token in seconds, defaults to %d", defaultWebTokenExpiresIn),
+			},
+			"token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"process_url": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (r *webTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*truora.TruoraClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *client.TruoraClient, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *webTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, span := startOperationSpan(ctx, webTokenTypeName, "open")
+	defer func() { endFrameworkSpan(span, resp.Diagnostics) }()
+
+	var model webTokenModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	country := model.Country.ValueString()
+	if country == "" {
+		country = "ALL"
+	}
+
+	expiresIn := int64(defaultWebTokenExpiresIn)
+	if !model.ExpiresIn.IsNull() {
+		expiresIn = model.ExpiresIn.ValueInt64()
+	}
+
+	if expiresIn < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_in"), "Invalid expires_in", "expires_in must be at least 1 second")
+		return
+	}
+
+	token, err := r.client.CreateWebIntegrationToken(ctx, &truora.WebIntegrationTokenRequest{
+		FlowID:      model.FlowID.ValueString(),
+		Country:     country,
+		RedirectURL: model.RedirectURL.ValueString(),
+		ExpiresIn:   expiresIn,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating web token", err.Error())
+		return
+	}
+
+	keyName, err := json.Marshal(token.KeyName)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating web token", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, webTokenKeyNamePrivateKey, keyName)...)
+
+	model.Country = types.StringValue(country)
+	model.ExpiresIn = types.Int64Value(expiresIn)
+	model.Token = types.StringValue(token.Key)
+	model.ProcessURL = types.StringValue(r.client.ProcessURL(token.Key))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+// Close deletes the API key of the token, so it can't be used after the run
+func (r *webTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	ctx, span := startOperationSpan(ctx, webTokenTypeName, "close")
+	defer func() { endFrameworkSpan(span, resp.Diagnostics) }()
+
+	keyNameJSON, diags := req.Private.GetKey(ctx, webTokenKeyNamePrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || keyNameJSON == nil {
+		return
+	}
+
+	var keyName string
+	if err := json.Unmarshal(keyNameJSON, &keyName); err != nil {
+		resp.Diagnostics.AddError("Error deleting web token", err.Error())
+		return
+	}
+
+	if keyName == "" {
+		return
+	}
+
+	if err := r.client.DeleteAPIKey(ctx, keyName); err != nil {
+		resp.Diagnostics.AddError("Error deleting web token", err.Error())
+	}
+}
@@ -0,0 +1,75 @@
+package truora
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+func TestAccEphemeralWebToken_basic(t *testing.T) {
+	server := testAccFakeServer(t)
+	recorder := testSpanRecorder(t)
+	t.Setenv(truora.WebServerEnvironmentVariableName, "https://identity.example.com")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"echo": echoprovider.NewProviderServer(),
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEphemeralWebTokenConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("expires_in"), knownvalue.Int64Exact(defaultWebTokenExpiresIn)),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("process_url"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^https://identity\.example\.com/\?token=KEY`))),
+				},
+				Check: func(s *terraform.State) error {
+					// the key is deleted when Terraform closes the ephemeral resource
+					if keys := server.APIKeys(); len(keys) != 0 {
+						return fmt.Errorf("expected every web token to be deleted, got %v", keys)
+					}
+
+					spans := map[string]bool{}
+					for _, span := range recorder.Ended() {
+						spans[span.Name()] = true
+					}
+
+					for _, name := range []string{"truora_web_token.open", "truora_web_token.close"} {
+						if !spans[name] {
+							return fmt.Errorf("expected a %s span", name)
+						}
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+const testAccEphemeralWebTokenConfig = `
+ephemeral "truora_web_token" "test" {
+  flow_id = "IPF123"
+}
+
+provider "echo" {
+  data = ephemeral.truora_web_token.test
+}
+
+resource "echo" "test" {}
+`
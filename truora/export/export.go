@@ -0,0 +1,407 @@
+// Package export renders existing flows as Terraform configuration, so
+// accounts built in the dashboard can be brought under Terraform.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+// Format selects how the flow document is written
+type Format string
+
+const (
+	// FormatHCL writes a truora_flow_document data source with HCL blocks
+	FormatHCL Format = "hcl"
+	// FormatJSON writes the document inline with jsonencode
+	FormatJSON Format = "json"
+)
+
+// documentKeys are the flow fields that make up a flow document, the rest
+// are set by the API
+var documentKeys = []string{"name", "type", "config", "identity_verifications"}
+
+// serverKeys are IDs assigned by the API, they are left out of documents
+var serverKeys = map[string]bool{"verification_id": true, "step_id": true}
+
+var invalidLabelCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Options configures Flows
+type Options struct {
+	OutDir string
+	Format Format
+	// Concurrency limits how many flows are fetched at once
+	Concurrency int
+}
+
+// Flows writes a .tf file per flow of the account in opts.OutDir, with an
+// import block for the flow. Flows that fail to be fetched are skipped and
+// reported in the returned error, the paths of the files written are
+// returned either way.
+func Flows(ctx context.Context, client *truora.TruoraClient, opts Options) ([]string, error) {
+	if opts.Format != FormatHCL && opts.Format != FormatJSON {
+		return nil, fmt.Errorf("unknown format %q, expected %s or %s", opts.Format, FormatHCL, FormatJSON)
+	}
+
+	listed, err := client.ListFlows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(listed))
+	for i, flow := range listed {
+		ids[i] = flow.FlowID
+	}
+
+	results, fetchErr := client.GetFlows(ctx, ids, &truora.GetFlowsOptions{Concurrency: opts.Concurrency})
+
+	if err := os.MkdirAll(opts.OutDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	labels := map[string]bool{}
+
+	var paths []string
+
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+
+		label := uniqueLabel(labels, result.Flow.Name)
+
+		content, err := RenderFlow(result.Flow, label, opts.Format)
+		if err != nil {
+			return paths, fmt.Errorf("error rendering flow %s: %w", result.FlowID, err)
+		}
+
+		path := filepath.Join(opts.OutDir, label+".tf")
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return paths, err
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths, fetchErr
+}
+
+// RenderFlow returns the configuration of a flow: an import block, the
+// truora_flow resource and, in FormatHCL, the truora_flow_document it uses
+func RenderFlow(flow *truora.IdentityProcessFlowResponse, label string, format Format) ([]byte, error) {
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+
+	importBlock := body.AppendNewBlock("import", nil)
+	importBlock.Body().SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: "truora_flow"},
+		hcl.TraverseAttr{Name: label},
+	})
+	importBlock.Body().SetAttributeValue("id", cty.StringVal(flow.FlowID))
+
+	body.AppendNewline()
+
+	var document hclwrite.Tokens
+
+	switch format {
+	case FormatHCL:
+		if err := appendFlowDocument(body, flow, label); err != nil {
+			return nil, err
+		}
+
+		body.AppendNewline()
+
+		document = hclwrite.TokensForTraversal(hcl.Traversal{
+			hcl.TraverseRoot{Name: "data"},
+			hcl.TraverseAttr{Name: "truora_flow_document"},
+			hcl.TraverseAttr{Name: label},
+			hcl.TraverseAttr{Name: "json"},
+		})
+	case FormatJSON:
+		value, err := flowDocumentValue(flow)
+		if err != nil {
+			return nil, err
+		}
+
+		document = hclwrite.TokensForFunctionCall("jsonencode", hclwrite.TokensForValue(value))
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	resourceBlock := body.AppendNewBlock("resource", []string{"truora_flow", label})
+	resourceBlock.Body().SetAttributeRaw("document", document)
+
+	return hclwrite.Format(file.Bytes()), nil
+}
+
+func appendFlowDocument(body *hclwrite.Body, flow *truora.IdentityProcessFlowResponse, label string) error {
+	documentBody := body.AppendNewBlock("data", []string{"truora_flow_document", label}).Body()
+
+	documentBody.SetAttributeValue("name", cty.StringVal(flow.Name))
+	setOptionalString(documentBody, "type", flow.Type)
+
+	if flow.Config != nil {
+		appendConfig(documentBody, flow.Config)
+	}
+
+	// verifications that can't be blocks are written as verification_json,
+	// the blocks get the position that keeps the original order
+	var fragments []hclwrite.Tokens
+	for _, verification := range flow.IdentityVerifications {
+		if verificationFitsBlock(verification) {
+			continue
+		}
+
+		value, err := documentValue(verification)
+		if err != nil {
+			return err
+		}
+
+		fragments = append(fragments, hclwrite.TokensForFunctionCall("jsonencode", hclwrite.TokensForValue(value)))
+	}
+
+	if len(fragments) > 0 {
+		documentBody.SetAttributeRaw("verification_json", hclwrite.TokensForTuple(fragments))
+	}
+
+	for i, verification := range flow.IdentityVerifications {
+		if !verificationFitsBlock(verification) {
+			continue
+		}
+
+		position := -1
+		if len(fragments) > 0 {
+			position = i
+		}
+
+		appendVerification(documentBody, verification, position)
+	}
+
+	return nil
+}
+
+func appendConfig(body *hclwrite.Body, config *truora.IdentityFlowConfig) {
+	body.AppendNewline()
+
+	configBody := body.AppendNewBlock("config", nil).Body()
+
+	setOptionalString(configBody, "lang", config.Lang)
+	configBody.SetAttributeValue("enable_desktop_flow", cty.BoolVal(config.EnableDesktopFlow))
+	setOptionalBool(configBody, "continue_flow_in_new_device", config.ContinueFlowInNewDevice)
+	setOptionalBool(configBody, "enable_postponed_web_process", config.EnablePostponedWebProcess)
+	setOptionalBool(configBody, "ignore_initial_message", config.IgnoreInitialMessage)
+	// follow_up_delay and follow_up_message require enable_follow_up
+	if config.FollowUpDelay > 0 || config.FollowUpMessage != "" {
+		configBody.SetAttributeValue("enable_follow_up", cty.BoolVal(config.EnableFollowUp))
+	} else {
+		setOptionalBool(configBody, "enable_follow_up", config.EnableFollowUp)
+	}
+
+	if config.FollowUpDelay > 0 {
+		configBody.SetAttributeValue("follow_up_delay", cty.NumberIntVal(config.FollowUpDelay))
+	}
+
+	setOptionalString(configBody, "follow_up_message", config.FollowUpMessage)
+	setOptionalTime(configBody, "start_business_hours", config.StartBusinessHours)
+	setOptionalTime(configBody, "end_business_hours", config.EndBusinessHours)
+
+	if config.Messages == nil {
+		return
+	}
+
+	messagesBody := configBody.AppendNewBlock("messages", nil).Body()
+
+	setOptionalString(messagesBody, "failure_message", config.Messages.FailureMessage)
+	setOptionalString(messagesBody, "success_message", config.Messages.SuccessMessage)
+	setOptionalString(messagesBody, "pending_message", config.Messages.PendingMessage)
+	setOptionalString(messagesBody, "exit_message", config.Messages.ExitMessage)
+	setOptionalString(messagesBody, "waiting_for_results_message", config.Messages.WaitingForResultsMessage)
+
+	for _, customMessage := range config.Messages.CustomMessages {
+		customMessageBody := messagesBody.AppendNewBlock("custom_messages", nil).Body()
+		customMessageBody.SetAttributeValue("message", cty.StringVal(customMessage.Message))
+		customMessageBody.SetAttributeValue("status", cty.StringVal(customMessage.Status))
+	}
+}
+
+// verificationFitsBlock reports whether verification can be written as a
+// verification block, whose config only holds strings
+func verificationFitsBlock(verification *truora.IdentityVerification) bool {
+	for _, value := range verification.Config {
+		if _, ok := value.(string); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// appendVerification writes a verification block, position is left out when
+// it is negative
+func appendVerification(body *hclwrite.Body, verification *truora.IdentityVerification, position int) {
+	body.AppendNewline()
+
+	verificationBody := body.AppendNewBlock("verification", nil).Body()
+	verificationBody.SetAttributeValue("name", cty.StringVal(verification.Name))
+
+	if position >= 0 {
+		verificationBody.SetAttributeValue("position", cty.NumberIntVal(int64(position)))
+	}
+
+	if len(verification.Config) > 0 {
+		config := make(map[string]cty.Value, len(verification.Config))
+		for key, value := range verification.Config {
+			config[key] = cty.StringVal(value.(string))
+		}
+
+		verificationBody.SetAttributeValue("config", cty.MapVal(config))
+	}
+
+	if len(verification.Logic) > 0 {
+		logic := make([]cty.Value, len(verification.Logic))
+		for i, condition := range verification.Logic {
+			logic[i] = cty.StringVal(condition)
+		}
+
+		verificationBody.SetAttributeValue("logic", cty.ListVal(logic))
+	}
+
+	for _, step := range verification.Steps {
+		stepBody := verificationBody.AppendNewBlock("steps", nil).Body()
+		stepBody.SetAttributeValue("type", cty.StringVal(step.Type))
+		setOptionalString(stepBody, "title", step.Title)
+		setOptionalString(stepBody, "description", step.Description)
+
+		for _, input := range step.ExpectedInputs {
+			inputBody := stepBody.AppendNewBlock("expected_inputs", nil).Body()
+			inputBody.SetAttributeValue("type", cty.StringVal(input.Type))
+			inputBody.SetAttributeValue("name", cty.StringVal(input.Name))
+
+			for _, option := range input.ResponseOptions {
+				optionBody := inputBody.AppendNewBlock("response_options", nil).Body()
+				optionBody.SetAttributeValue("value", cty.StringVal(option.Value))
+				setOptionalString(optionBody, "alias", option.Alias)
+			}
+		}
+	}
+}
+
+// flowDocumentValue returns the document a truora_flow would be created
+// with, the fields set by the API are left out
+func flowDocumentValue(flow *truora.IdentityProcessFlowResponse) (cty.Value, error) {
+	flowMarshal, err := json.Marshal(flow)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	var flowMap map[string]interface{}
+	if err := json.Unmarshal(flowMarshal, &flowMap); err != nil {
+		return cty.NilVal, err
+	}
+
+	document := map[string]interface{}{}
+	for _, key := range documentKeys {
+		if v, ok := flowMap[key]; ok && v != nil {
+			document[key] = v
+		}
+	}
+
+	return documentValue(document)
+}
+
+// documentValue converts v to a cty value through JSON, dropping the IDs set
+// by the API
+func documentValue(v interface{}) (cty.Value, error) {
+	marshalled, err := json.Marshal(v)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	var generic interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(string(marshalled)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return cty.NilVal, err
+	}
+
+	marshalled, err = json.Marshal(dropServerKeys(generic))
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	valueType, err := ctyjson.ImpliedType(marshalled)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	return ctyjson.Unmarshal(marshalled, valueType)
+}
+
+func dropServerKeys(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if serverKeys[key] {
+				delete(value, key)
+				continue
+			}
+
+			value[key] = dropServerKeys(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = dropServerKeys(item)
+		}
+	}
+
+	return v
+}
+
+// uniqueLabel turns a flow name into a Terraform label that hasn't been used
+func uniqueLabel(used map[string]bool, name string) string {
+	base := strings.Trim(invalidLabelCharacters.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "flow_" + base
+	}
+
+	label := base
+	for i := 2; used[label]; i++ {
+		label = fmt.Sprintf("%s_%d", base, i)
+	}
+
+	used[label] = true
+
+	return label
+}
+
+func setOptionalString(body *hclwrite.Body, name, value string) {
+	if value != "" {
+		body.SetAttributeValue(name, cty.StringVal(value))
+	}
+}
+
+func setOptionalBool(body *hclwrite.Body, name string, value bool) {
+	if value {
+		body.SetAttributeValue(name, cty.BoolVal(value))
+	}
+}
+
+func setOptionalTime(body *hclwrite.Body, name string, value *time.Time) {
+	if value != nil {
+		body.SetAttributeValue(name, cty.StringVal(value.Format(time.RFC3339)))
+	}
+}
@@ -0,0 +1,105 @@
+package export_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+	"terraform-provider-truora/truora/export"
+)
+
+func TestFlows(t *testing.T) {
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	server.AddFlow(&truora.IdentityProcessFlow{
+		Name: "Onboarding flow",
+		Type: "permanent",
+		Config: &truora.IdentityFlowConfig{
+			Lang:              "en",
+			EnableDesktopFlow: true,
+			Messages:          &truora.Messages{SuccessMessage: "Done"},
+		},
+		IdentityVerifications: []*truora.IdentityVerification{
+			{
+				Name:   "phone_verification",
+				Config: map[string]interface{}{"country": "CO"},
+				Steps:  []*truora.Step{{Type: "phone-verification", Title: "Phone"}},
+			},
+		},
+	})
+
+	server.AddFlow(&truora.IdentityProcessFlow{
+		Name: "Onboarding flow",
+		Type: "temporary",
+		IdentityVerifications: []*truora.IdentityVerification{
+			{Name: "document_validation", Config: map[string]interface{}{"retries": 3}},
+		},
+	})
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []export.Format{export.FormatHCL, export.FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			outDir := t.TempDir()
+
+			paths, err := export.Flows(context.Background(), client, export.Options{OutDir: outDir, Format: format})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(paths) != 2 {
+				t.Fatalf("expected 2 files, got %v", paths)
+			}
+
+			parser := hclparse.NewParser()
+
+			var contents []string
+			for _, path := range paths {
+				if _, diags := parser.ParseHCLFile(path); diags.HasErrors() {
+					t.Fatalf("%s is not valid HCL: %s", path, diags)
+				}
+
+				content, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				contents = append(contents, string(content))
+			}
+
+			all := strings.Join(contents, "\n")
+
+			for _, expected := range []string{"import {", `resource "truora_flow" "onboarding_flow"`, `resource "truora_flow" "onboarding_flow_2"`} {
+				if !strings.Contains(all, expected) {
+					t.Fatalf("expected %q in:\n%s", expected, all)
+				}
+			}
+
+			if strings.Contains(all, "verification_id") || strings.Contains(all, "step_id") {
+				t.Fatalf("expected server IDs to be left out:\n%s", all)
+			}
+
+			if format == export.FormatHCL && (!strings.Contains(all, "verification {") || !strings.Contains(all, "verification_json = [jsonencode(")) {
+				t.Fatalf("expected verification blocks and verification_json:\n%s", all)
+			}
+
+			if format == export.FormatJSON && !strings.Contains(all, "document = jsonencode(") {
+				t.Fatalf("expected jsonencode documents:\n%s", all)
+			}
+
+			if _, err := os.Stat(filepath.Join(outDir, "onboarding_flow.tf")); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
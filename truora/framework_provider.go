@@ -0,0 +1,224 @@
+package truora
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+var (
+	_ provider.Provider                       = &frameworkProvider{}
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+)
+
+// frameworkProvider serves the features the SDK can't, like ephemeral
+// resources. It is muxed with Provider() so its schema must match it.
+type frameworkProvider struct {
+	// sdkProvider is the provider it is muxed with, its client is reused so
+	// both share the rate limit and the flow cache
+	sdkProvider *sdkschema.Provider
+}
+
+type frameworkProviderModel struct {
+	APIKey             types.String              `tfsdk:"api_key"`
+	APIServer          types.String              `tfsdk:"api_server"`
+	Endpoints          []frameworkEndpointsModel `tfsdk:"endpoints"`
+	ProxyURL           types.String              `tfsdk:"proxy_url"`
+	CACertPEM          types.String              `tfsdk:"ca_cert_pem"`
+	ClientCertPEM      types.String              `tfsdk:"client_cert_pem"`
+	ClientKeyPEM       types.String              `tfsdk:"client_key_pem"`
+	InsecureSkipVerify types.Bool                `tfsdk:"insecure_skip_verify"`
+	RequestsPerSecond  types.Float64             `tfsdk:"requests_per_second"`
+	Burst              types.Int64               `tfsdk:"burst"`
+	CacheFlows         types.Bool                `tfsdk:"cache_flows"`
+}
+
+type frameworkEndpointsModel struct {
+	Identity    types.String `tfsdk:"identity"`
+	Checks      types.String `tfsdk:"checks"`
+	Account     types.String `tfsdk:"account"`
+	Validations types.String `tfsdk:"validations"`
+	Connect     types.String `tfsdk:"connect"`
+	Web         types.String `tfsdk:"web"`
+}
+
+// NewFrameworkProvider -
+func NewFrameworkProvider() provider.Provider {
+	return &frameworkProvider{}
+}
+
+// ProviderServers returns the SDK and framework providers to mux. The mux
+// server configures them in order, so the framework provider gets the client
+// the SDK provider built instead of building its own.
+func ProviderServers() []func() tfprotov5.ProviderServer {
+	sdkProvider := Provider()
+
+	return []func() tfprotov5.ProviderServer{
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(&frameworkProvider{sdkProvider: sdkProvider}),
+	}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "truora"
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"api_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: apiKeyDescription,
+			},
+			"api_server": schema.StringAttribute{
+				Optional:           true,
+				Description:        apiServerDescription,
+				DeprecationMessage: apiServerDeprecation,
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: proxyURLDescription,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: caCertPEMDescription,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: clientCertPEMDescription,
+			},
+			"client_key_pem": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: clientKeyPEMDescription,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: insecureSkipVerifyDescription,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: requestsPerSecondDescription,
+			},
+			"burst": schema.Int64Attribute{
+				Optional:    true,
+				Description: burstDescription,
+			},
+			"cache_flows": schema.BoolAttribute{
+				Optional:    true,
+				Description: cacheFlowsDescription,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"endpoints": schema.ListNestedBlock{
+				Description: endpointsDescription,
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: frameworkEndpointsAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func frameworkEndpointsAttributes() map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{}
+	for name, description := range endpointDescriptions {
+		attributes[name] = schema.StringAttribute{
+			Optional:    true,
+			Description: description,
+		}
+	}
+
+	return attributes
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var model frameworkProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if p.sdkProvider != nil {
+		if client, ok := p.sdkProvider.Meta().(*truora.TruoraClient); ok {
+			resp.EphemeralResourceData = client
+			return
+		}
+	}
+
+	// unset values fall back to the environment in truora.NewClient
+	config := Config{
+		APIKey:             model.APIKey.ValueString(),
+		APIServer:          model.APIServer.ValueString(),
+		UserAgent:          fmt.Sprintf("Terraform/%s (+https://www.terraform.io) %s/%s", req.TerraformVersion, truora.DefaultUserAgent, Version),
+		ProxyURL:           model.ProxyURL.ValueString(),
+		CACertPEM:          model.CACertPEM.ValueString(),
+		ClientCertPEM:      model.ClientCertPEM.ValueString(),
+		ClientKeyPEM:       model.ClientKeyPEM.ValueString(),
+		InsecureSkipVerify: model.InsecureSkipVerify.ValueBool(),
+		RequestsPerSecond:  model.RequestsPerSecond.ValueFloat64(),
+		Burst:              int(model.Burst.ValueInt64()),
+		CacheFlows:         model.CacheFlows.ValueBool(),
+	}
+
+	if len(model.Endpoints) > 0 {
+		endpoints := model.Endpoints[0]
+
+		config.Endpoints = truora.Endpoints{
+			Identity:    endpoints.Identity.ValueString(),
+			Checks:      endpoints.Checks.ValueString(),
+			Account:     endpoints.Account.ValueString(),
+			Validations: endpoints.Validations.ValueString(),
+			Connect:     endpoints.Connect.ValueString(),
+			Web:         endpoints.Web.ValueString(),
+		}
+	}
+
+	// warnings are skipped, the SDK provider is configured with the same
+	// values and already reports them
+	client, diags := config.Client()
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			resp.Diagnostics.AddError(d.Summary, d.Detail)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.EphemeralResourceData = client
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newWebTokenEphemeralResource,
+	}
+}
@@ -0,0 +1,216 @@
+package truora
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// mergeFlowDocument layers the document built from the HCL arguments on top
+// of sourceJSON and then layers overrideJSON on top of the result. The layers
+// are merged as JSON objects, so keys the provider doesn't model are kept.
+//
+// The merge follows these rules:
+//   - top level attributes and config keys from the upper layer overwrite the lower one
+//   - identity_verifications are matched by name and merged, unmatched ones are appended
+//   - steps are matched by step_id, or by type when either side has no step_id
+//   - any other list (if, expected_inputs, custom_messages) is replaced as a whole
+//   - empty values never overwrite a lower layer, false and 0 do
+//   - config.lang is "es" when the HCL has a config block and no layer sets lang
+func mergeFlowDocument(document map[string]interface{}, sourceJSON, overrideJSON string) (map[string]interface{}, error) {
+	merged := map[string]interface{}{}
+
+	if sourceJSON != "" {
+		source, err := decodeFlowDocument(sourceJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding source_json: %w", err)
+		}
+
+		merged = mergeFlowMaps(merged, source)
+	}
+
+	merged = mergeFlowMaps(merged, document)
+
+	if overrideJSON != "" {
+		override, err := decodeFlowDocument(overrideJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding override_json: %w", err)
+		}
+
+		merged = mergeFlowMaps(merged, override)
+	}
+
+	if _, ok := document["config"]; ok {
+		if config, ok := merged["config"].(map[string]interface{}); ok && isEmptyJSONValue(config["lang"]) {
+			config["lang"] = defaultFlowLang
+		}
+	}
+
+	return merged, nil
+}
+
+func validateFlowDocumentJSON(v interface{}, path cty.Path) diag.Diagnostics {
+	if _, err := decodeFlowDocument(v.(string)); err != nil {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid flow document",
+				Detail:        err.Error(),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
+
+func decodeFlowDocument(document string) (map[string]interface{}, error) {
+	flowMap, err := decodeJSONObject(document)
+	if err != nil {
+		return nil, err
+	}
+
+	if flowMap == nil {
+		return nil, fmt.Errorf("flow document must be a JSON object")
+	}
+
+	return flowMap, nil
+}
+
+// decodeJSONObject keeps numbers as json.Number so large integers survive
+// the merge unchanged
+func decodeJSONObject(document string) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON object")
+	}
+
+	return object, nil
+}
+
+func mergeFlowMaps(base, overlay map[string]interface{}) map[string]interface{} {
+	return mergeObjects(base, overlay, map[string]listMerger{
+		"identity_verifications": mergeVerificationLists,
+	})
+}
+
+func mergeVerificationMaps(base, overlay map[string]interface{}) map[string]interface{} {
+	return mergeObjects(base, overlay, map[string]listMerger{
+		"steps": mergeStepLists,
+	})
+}
+
+func mergeVerificationLists(base, overlay []interface{}) []interface{} {
+	return mergeListsByKey(base, overlay, func(a, b map[string]interface{}) bool {
+		return getStringFromMap(a, "name") != "" && getStringFromMap(a, "name") == getStringFromMap(b, "name")
+	}, mergeVerificationMaps)
+}
+
+func mergeStepLists(base, overlay []interface{}) []interface{} {
+	return mergeListsByKey(base, overlay, func(a, b map[string]interface{}) bool {
+		aID, bID := getStringFromMap(a, "step_id"), getStringFromMap(b, "step_id")
+		if aID != "" && bID != "" {
+			return aID == bID
+		}
+
+		return getStringFromMap(a, "type") != "" && getStringFromMap(a, "type") == getStringFromMap(b, "type")
+	}, func(a, b map[string]interface{}) map[string]interface{} {
+		return mergeObjects(a, b, nil)
+	})
+}
+
+type listMerger func(base, overlay []interface{}) []interface{}
+
+// mergeObjects returns a copy of base with the keys of overlay merged on top.
+// Nested objects are merged recursively and lists are replaced, unless a
+// listMerger is registered for the key.
+func mergeObjects(base, overlay map[string]interface{}, listMergers map[string]listMerger) map[string]interface{} {
+	result := make(map[string]interface{}, len(base)+len(overlay))
+	for k, v := range base {
+		result[k] = v
+	}
+
+	for k, v := range overlay {
+		if isEmptyJSONValue(v) {
+			continue
+		}
+
+		baseList, baseIsList := result[k].([]interface{})
+		overlayList, overlayIsList := v.([]interface{})
+		if merger, ok := listMergers[k]; ok && baseIsList && overlayIsList {
+			result[k] = merger(baseList, overlayList)
+			continue
+		}
+
+		baseObject, baseIsObject := result[k].(map[string]interface{})
+		overlayObject, overlayIsObject := v.(map[string]interface{})
+		if baseIsObject && overlayIsObject {
+			result[k] = mergeObjects(baseObject, overlayObject, nil)
+			continue
+		}
+
+		result[k] = v
+	}
+
+	return result
+}
+
+// mergeListsByKey merges every overlay object into the first base object that
+// matches it, appending the ones that match nothing. Order of base is kept.
+func mergeListsByKey(
+	base, overlay []interface{},
+	matches func(a, b map[string]interface{}) bool,
+	merge func(a, b map[string]interface{}) map[string]interface{},
+) []interface{} {
+	result := make([]interface{}, len(base), len(base)+len(overlay))
+	copy(result, base)
+
+	for _, item := range overlay {
+		overlayObject, ok := item.(map[string]interface{})
+		if !ok {
+			result = append(result, item)
+			continue
+		}
+
+		merged := false
+		for i, existing := range result {
+			baseObject, ok := existing.(map[string]interface{})
+			if ok && matches(baseObject, overlayObject) {
+				result[i] = merge(baseObject, overlayObject)
+				merged = true
+				break
+			}
+		}
+
+		if !merged {
+			result = append(result, overlayObject)
+		}
+	}
+
+	return result
+}
+
+func isEmptyJSONValue(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+
+	return false
+}
@@ -0,0 +1,169 @@
+package truora
+
+import (
+	"encoding/json"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+)
+
+// flowDocumentFromTestConfig builds the document of a truora_flow_document
+// configuration written as JSON, attributes left out are null like in HCL
+func flowDocumentFromTestConfig(t *testing.T, config string) map[string]interface{} {
+	t.Helper()
+
+	configType := dataSourceFlowDocument().CoreConfigSchema().ImpliedType()
+
+	value, err := ctyjson.Unmarshal([]byte(config), configType)
+	if err != nil {
+		t.Fatalf("error decoding config: %s", err)
+	}
+
+	document, err := flowDocumentFromConfig(value)
+	if err != nil {
+		t.Fatalf("error building document: %s", err)
+	}
+
+	return document
+}
+
+func TestMergeFlowDocument(t *testing.T) {
+	cases := []struct {
+		name         string
+		config       string
+		sourceJSON   string
+		overrideJSON string
+		expected     string
+	}{
+		{
+			name:       "hcl overwrites source",
+			config:     `{"name": "hcl flow"}`,
+			sourceJSON: `{"name": "source flow", "type": "temporary"}`,
+			expected:   `{"name": "hcl flow", "type": "temporary"}`,
+		},
+		{
+			name:         "override overwrites hcl",
+			config:       `{"name": "hcl flow"}`,
+			overrideJSON: `{"name": "override flow"}`,
+			expected:     `{"name": "override flow"}`,
+		},
+		{
+			name:       "unmodelled keys are kept",
+			config:     `{"config": [{"enable_desktop_flow": true}]}`,
+			sourceJSON: `{"config": {"redirect_urls": {"success": "https://example.com"}, "time_to_live": 3600}, "labels": ["a"]}`,
+			expected:   `{"config": {"enable_desktop_flow": true, "lang": "es", "redirect_urls": {"success": "https://example.com"}, "time_to_live": 3600}, "labels": ["a"]}`,
+		},
+		{
+			name:       "explicit false overwrites source",
+			config:     `{"config": [{"enable_desktop_flow": false}]}`,
+			sourceJSON: `{"config": {"enable_desktop_flow": true, "enable_follow_up": true}}`,
+			expected:   `{"config": {"enable_desktop_flow": false, "enable_follow_up": true, "lang": "es"}}`,
+		},
+		{
+			name:       "source lang is kept when hcl doesn't set it",
+			config:     `{"config": [{"enable_desktop_flow": true}]}`,
+			sourceJSON: `{"config": {"lang": "pt"}}`,
+			expected:   `{"config": {"enable_desktop_flow": true, "lang": "pt"}}`,
+		},
+		{
+			name:         "override lang is kept when hcl doesn't set it",
+			config:       `{"config": [{"enable_desktop_flow": true}]}`,
+			overrideJSON: `{"config": {"lang": "en"}}`,
+			expected:     `{"config": {"enable_desktop_flow": true, "lang": "en"}}`,
+		},
+		{
+			name:     "lang defaults to es when no layer sets it",
+			config:   `{"config": [{"enable_desktop_flow": true}]}`,
+			expected: `{"config": {"enable_desktop_flow": true, "lang": "es"}}`,
+		},
+		{
+			name:       "lang has no default without a config block",
+			sourceJSON: `{"config": {"time_to_live": 3600}}`,
+			expected:   `{"config": {"time_to_live": 3600}}`,
+		},
+		{
+			name:         "empty values don't overwrite",
+			config:       `{"name": ""}`,
+			sourceJSON:   `{"name": "source flow"}`,
+			overrideJSON: `{"name": "", "identity_verifications": []}`,
+			expected:     `{"name": "source flow"}`,
+		},
+		{
+			name:       "verifications are merged by name",
+			config:     `{"verification": [{"name": "email_verification", "logic": ["a"]}, {"name": "phone_verification"}]}`,
+			sourceJSON: `{"identity_verifications": [{"name": "email_verification", "verification_id": "VER1", "if": ["b"]}]}`,
+			expected:   `{"identity_verifications": [{"name": "email_verification", "verification_id": "VER1", "if": ["a"]}, {"name": "phone_verification"}]}`,
+		},
+		{
+			name:         "steps are merged by step_id then type",
+			sourceJSON:   `{"identity_verifications": [{"name": "v", "steps": [{"step_id": "S1", "type": "a", "title": "one"}, {"type": "b", "title": "two"}]}]}`,
+			overrideJSON: `{"identity_verifications": [{"name": "v", "steps": [{"step_id": "S1", "title": "uno"}, {"step_id": "S2", "type": "b"}, {"type": "c"}]}]}`,
+			expected:     `{"identity_verifications": [{"name": "v", "steps": [{"step_id": "S1", "type": "a", "title": "uno"}, {"step_id": "S2", "type": "b", "title": "two"}, {"type": "c"}]}]}`,
+		},
+		{
+			name:       "other lists are replaced",
+			config:     `{"config": [{"enable_desktop_flow": true, "messages": [{"custom_messages": [{"message": "hi", "status": "success"}]}]}]}`,
+			sourceJSON: `{"config": {"messages": {"exit_message": "bye", "custom_messages": [{"message": "a", "status": "failure"}, {"message": "b", "status": "pending"}]}}}`,
+			expected:   `{"config": {"enable_desktop_flow": true, "lang": "es", "messages": {"exit_message": "bye", "custom_messages": [{"message": "hi", "status": "success"}]}}}`,
+		},
+		{
+			name:       "numbers are kept as written",
+			sourceJSON: `{"config": {"follow_up_delay": 9007199254740993}}`,
+			expected:   `{"config": {"follow_up_delay": 9007199254740993}}`,
+		},
+		{
+			name:     "business hours are converted to timestamps",
+			config:   `{"config": [{"enable_desktop_flow": true, "start_business_hours": "09:00", "end_business_hours": "2024-01-01T18:00:00-05:00"}]}`,
+			expected: `{"config": {"enable_desktop_flow": true, "lang": "es", "start_business_hours": "0000-01-01T09:00:00Z", "end_business_hours": "2024-01-01T18:00:00-05:00"}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := tc.config
+			if config == "" {
+				config = "{}"
+			}
+
+			document, err := mergeFlowDocument(flowDocumentFromTestConfig(t, config), tc.sourceJSON, tc.overrideJSON)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			actual, err := canonicalJSON(mustMarshal(t, document))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected, err := canonicalJSON([]byte(tc.expected))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != string(expected) {
+				t.Fatalf("expected:\n%s\ngot:\n%s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestMergeFlowDocument_invalidJSON(t *testing.T) {
+	if _, err := mergeFlowDocument(map[string]interface{}{}, `[]`, ""); err == nil {
+		t.Fatal("expected an error for a source_json that isn't an object")
+	}
+
+	if _, err := mergeFlowDocument(map[string]interface{}{}, "", `{"name": "a"} {}`); err == nil {
+		t.Fatal("expected an error for an override_json with trailing data")
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
@@ -1,85 +1,40 @@
 package truora
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	truora "terraform-provider-truora/truora/client"
 )
 
-func parseCustomMessages(customMessages interface{}) []*truora.CustomFinalMessage {
-	if customMessagesSlice, ok := customMessages.([]interface{}); ok {
-
-		customFinalMessages := make([]*truora.CustomFinalMessage, len(customMessagesSlice))
-		for i, customMessageMap := range customMessagesSlice {
-			if customMessage, ok := customMessageMap.(map[string]interface{}); ok {
-				customFinalMessages[i] = &truora.CustomFinalMessage{
-					Message: getStringFromMap(customMessage, "message"),
-					Status:  getStringFromMap(customMessage, "status"),
-				}
-			}
-		}
-		return customFinalMessages
-	}
-	return nil
-}
-func parseMessages(messagesMap map[string]interface{}) *truora.Messages {
-	if messagesMap == nil {
-		return nil
-	}
-
-	message := truora.Messages{
-		FailureMessage:           getStringFromMap(messagesMap, "failure_message"),
-		SuccessMessage:           getStringFromMap(messagesMap, "success_message"),
-		PendingMessage:           getStringFromMap(messagesMap, "pending_message"),
-		ExitMessage:              getStringFromMap(messagesMap, "exit_message"),
-		WaitingForResultsMessage: getStringFromMap(messagesMap, "waiting_for_results_message"),
-		CustomMessages:           parseCustomMessages(messagesMap["custom_messages"]),
-	}
-	return &message
-}
-
-func parseFlowConfig(data map[string]interface{}) *truora.IdentityFlowConfig {
-	flowConfig := &truora.IdentityFlowConfig{
-		EnableDesktopFlow:       getBoolFromMap(data, "enable_desktop_flow"),
-		Lang:                    getStringFromMap(data, "lang"),
-		ContinueFlowInNewDevice: getBoolFromMap(data, "continue_flow_in_new_device"),
-		EnableFollowUp:          getBoolFromMap(data, "enable_follow_up"),
-		FollowUpDelay:           getInt64FromMap(data, "follow_up_delay"),
-	}
-
-	flowConfig.FollowUpMessage = getStringFromMap(data, "follow_up_message")
-	flowConfig.StartBusinessHours = getTimeFromMap(data, "start_business_hours")
-	flowConfig.EndBusinessHours = getTimeFromMap(data, "end_business_hours")
-
-	messagesMap := getMapFromSingleElementList(data, "messages")
-	flowConfig.Messages = parseMessages(messagesMap)
-
-	return flowConfig
-}
-
-func parseVerifications(data []interface{}) []*truora.IdentityVerification {
-	verifications := make([]*truora.IdentityVerification, len(data))
-
-	for i, verificationMap := range data {
-		verification := parseVerification(verificationMap.(map[string]interface{}))
-		verifications[i] = verification
-	}
-
-	return verifications
-}
-
 func parseVerification(verificationMap map[string]interface{}) *truora.IdentityVerification {
 	verification := &truora.IdentityVerification{
 		Name: getStringFromMap(verificationMap, "name"),
 	}
 
 	verification.Logic = parseStringArray(verificationMap, "logic")
+	verification.Config = parseVerificationConfig(verificationMap)
 
 	parseSteps(verificationMap, verification)
 
 	return verification
 }
 
+func parseVerificationConfig(verificationMap map[string]interface{}) map[string]interface{} {
+	if v, ok := verificationMap["config"]; ok {
+		config := v.(map[string]interface{})
+		if len(config) > 0 {
+			return config
+		}
+	}
+	return nil
+}
+
 func parseSteps(verificationMap map[string]interface{}, verification *truora.IdentityVerification) {
 	if v, ok := verificationMap["steps"]; ok {
 		stepsMapList := v.([]interface{})
@@ -148,22 +103,177 @@ func parseResponseOption(responseOptionMap map[string]interface{}) *truora.Respo
 	}
 }
 
-func flowFromResourceData(d *schema.ResourceData) *truora.IdentityProcessFlow {
-	flow := truora.IdentityProcessFlow{
-		Name: d.Get("name").(string),
-		Type: d.Get("type").(string),
+// flowDocumentFromConfig builds the flow document of the HCL arguments from
+// the raw configuration. Only the values that are set end up in the document,
+// so an unset bool doesn't overwrite source_json and an explicit false does.
+func flowDocumentFromConfig(config cty.Value) (map[string]interface{}, error) {
+	document := map[string]interface{}{}
+
+	raw, ok := configToJSONValue(config).(map[string]interface{})
+	if !ok {
+		return document, nil
 	}
 
-	if v, ok := d.GetOk("config.0"); ok {
-		mapConfig := v.(map[string]interface{})
-		flow.Config = parseFlowConfig(mapConfig)
+	for _, key := range []string{"name", "type"} {
+		if v, ok := raw[key]; ok {
+			document[key] = v
+		}
 	}
 
-	if v, ok := d.GetOk("verification"); ok {
-		verificationsData := v.([]interface{})
-		verifications := parseVerifications(verificationsData)
-		flow.IdentityVerifications = verifications
+	if flowConfig := getMapFromSingleElementList(raw, "config"); flowConfig != nil {
+		if messages := getMapFromSingleElementList(flowConfig, "messages"); messages != nil {
+			flowConfig["messages"] = messages
+		}
+
+		for _, key := range []string{"start_business_hours", "end_business_hours"} {
+			if v, ok := flowConfig[key].(string); ok {
+				t, err := parseBusinessHours(v)
+				if err != nil {
+					return nil, fmt.Errorf("config.0.%s: %w", key, err)
+				}
+
+				flowConfig[key] = t.Format(time.RFC3339Nano)
+			}
+		}
+
+		document["config"] = flowConfig
+	}
+
+	var verifications []interface{}
+
+	fragments, _ := raw["verification_json"].([]interface{})
+	for _, fragment := range fragments {
+		verification, err := decodeJSONObject(fragment.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error decoding verification_json: %w", err)
+		}
+
+		if verification == nil {
+			return nil, fmt.Errorf("verification_json must be a JSON object")
+		}
+
+		verifications = append(verifications, verification)
+	}
+
+	var positioned []positionedVerification
+
+	blocks, _ := raw["verification"].([]interface{})
+	for _, block := range blocks {
+		verification := block.(map[string]interface{})
+		if logic, ok := verification["logic"]; ok {
+			verification["if"] = logic
+			delete(verification, "logic")
+		}
+
+		position, ok := verification["position"].(json.Number)
+		if !ok {
+			verifications = append(verifications, verification)
+			continue
+		}
+
+		delete(verification, "position")
+
+		index, err := position.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("verification position: %w", err)
+		}
+
+		positioned = append(positioned, positionedVerification{index: int(index), verification: verification})
+	}
+
+	verifications = insertVerifications(verifications, positioned)
+
+	if len(verifications) > 0 {
+		document["identity_verifications"] = verifications
+	}
+
+	return document, nil
+}
+
+type positionedVerification struct {
+	index        int
+	verification map[string]interface{}
+}
+
+// insertVerifications inserts the verification blocks with a position into
+// the list of fragments and blocks without one, which keep the order they
+// are written in. Positions past the end of the list append the block.
+func insertVerifications(verifications []interface{}, positioned []positionedVerification) []interface{} {
+	sort.SliceStable(positioned, func(i, j int) bool {
+		return positioned[i].index < positioned[j].index
+	})
+
+	for _, p := range positioned {
+		index := p.index
+		if index > len(verifications) {
+			index = len(verifications)
+		}
+
+		verifications = append(verifications, nil)
+		copy(verifications[index+1:], verifications[index:])
+		verifications[index] = p.verification
+	}
+
+	return verifications
+}
+
+// configToJSONValue converts a configuration value to the values of
+// encoding/json. Null values and empty collections are left out, numbers are
+// kept as json.Number.
+func configToJSONValue(v cty.Value) interface{} {
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+
+	t := v.Type()
+
+	switch {
+	case t == cty.String:
+		return v.AsString()
+	case t == cty.Bool:
+		return v.True()
+	case t == cty.Number:
+		return json.Number(v.AsBigFloat().Text('f', -1))
+	case t.IsListType() || t.IsSetType() || t.IsTupleType():
+		var list []interface{}
+		for it := v.ElementIterator(); it.Next(); {
+			_, element := it.Element()
+			if value := configToJSONValue(element); !isEmptyJSONValue(value) {
+				list = append(list, value)
+			}
+		}
+
+		if len(list) == 0 {
+			return nil
+		}
+
+		return list
+	case t.IsMapType() || t.IsObjectType():
+		object := map[string]interface{}{}
+		for it := v.ElementIterator(); it.Next(); {
+			key, element := it.Element()
+			if value := configToJSONValue(element); !isEmptyJSONValue(value) {
+				object[key.AsString()] = value
+			}
+		}
+
+		if len(object) == 0 {
+			return nil
+		}
+
+		return object
+	}
+
+	return nil
+}
+
+func verificationFromResourceData(d *schema.ResourceData) *truora.IdentityVerification {
+	verificationMap := map[string]interface{}{
+		"name":   d.Get("name"),
+		"config": d.Get("config"),
+		"logic":  d.Get("logic"),
+		"steps":  d.Get("steps"),
 	}
 
-	return &flow
+	return parseVerification(verificationMap)
 }
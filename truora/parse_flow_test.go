@@ -0,0 +1,55 @@
+package truora
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlowDocumentFromConfig_verificationOrder(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name:     "fragments before blocks without position",
+			config:   `{"verification_json": ["{\"name\":\"a\"}", "{\"name\":\"b\"}"], "verification": [{"name": "c"}, {"name": "d"}]}`,
+			expected: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:     "block between fragments",
+			config:   `{"verification_json": ["{\"name\":\"a\"}", "{\"name\":\"c\"}"], "verification": [{"name": "b", "position": 1}]}`,
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "blocks before fragments",
+			config:   `{"verification_json": ["{\"name\":\"c\"}"], "verification": [{"name": "b", "position": 1}, {"name": "a", "position": 0}]}`,
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "position past the end appends",
+			config:   `{"verification_json": ["{\"name\":\"a\"}"], "verification": [{"name": "c", "position": 10}, {"name": "b"}]}`,
+			expected: []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			document := flowDocumentFromTestConfig(t, tc.config)
+
+			var names []string
+			for _, verification := range document["identity_verifications"].([]interface{}) {
+				verificationMap := verification.(map[string]interface{})
+				if _, ok := verificationMap["position"]; ok {
+					t.Fatalf("position must not be sent to the API: %v", verificationMap)
+				}
+
+				names = append(names, getStringFromMap(verificationMap, "name"))
+			}
+
+			if !reflect.DeepEqual(names, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, names)
+			}
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package truora
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -10,49 +15,205 @@ import (
 	truora "terraform-provider-truora/truora/client"
 )
 
+// The provider schema is served by both the SDK and the framework providers,
+// descriptions are shared so both schemas stay identical.
+const (
+	apiKeyDescription      = "The API key for the Truora API"
+	apiServerDescription   = "The API server for the Truora API"
+	apiServerDeprecation   = "Use endpoints.identity instead"
+	endpointsDescription   = "Overrides the API server of each Truora product"
+	endpointDescriptionFmt = "The API server for the Truora %s API"
+	webEndpointDescription = "The URL of the Truora web application where Digital Identity processes are opened"
+
+	proxyURLDescription           = "The URL of the proxy used to reach the Truora API, by default the HTTPS_PROXY environment variable is used"
+	caCertPEMDescription          = "PEM encoded CA certificates trusted in addition to the system ones"
+	clientCertPEMDescription      = "PEM encoded client certificate for mutual TLS, requires client_key_pem"
+	clientKeyPEMDescription       = "PEM encoded private key of client_cert_pem"
+	insecureSkipVerifyDescription = "Skips verifying the TLS certificate of the Truora API, only meant for test environments"
+	requestsPerSecondDescription  = "Maximum number of requests per second sent to the Truora API, shared by all operations. Unlimited by default"
+	burstDescription              = "Number of requests that can be sent at once before requests_per_second applies. Defaults to 1"
+	cacheFlowsDescription         = "Caches the flows read from the Truora API for the rest of the run, flows changed by this provider are refreshed"
+)
+
+// Version is the provider version sent in the User-Agent, it is set at build
+// time with -ldflags "-X terraform-provider-truora/truora.Version=..."
+var Version = "dev"
+
+// endpointDescriptions has the attributes of the endpoints block, one per
+// product API plus the web application
+var endpointDescriptions = map[string]string{
+	"identity":    fmt.Sprintf(endpointDescriptionFmt, "identity"),
+	"checks":      fmt.Sprintf(endpointDescriptionFmt, "checks"),
+	"account":     fmt.Sprintf(endpointDescriptionFmt, "account"),
+	"validations": fmt.Sprintf(endpointDescriptionFmt, "validations"),
+	"connect":     fmt.Sprintf(endpointDescriptionFmt, "connect"),
+	"web":         webEndpointDescription,
+}
+
+// apiKeyExpirationWarningWindow is how long before expiration the provider
+// starts warning about the configured API key
+const apiKeyExpirationWarningWindow = 30 * 24 * time.Hour
+
 type Config struct {
-	APIKey    string
-	APIServer string
+	APIKey             string
+	APIServer          string
+	Endpoints          truora.Endpoints
+	UserAgent          string
+	ProxyURL           string
+	CACertPEM          string
+	ClientCertPEM      string
+	ClientKeyPEM       string
+	InsecureSkipVerify bool
+	RequestsPerSecond  float64
+	Burst              int
+	CacheFlows         bool
 }
 
 // Provider -
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_key": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("TRUORA_API_KEY", nil),
-				Description: "The API key for the Truora API",
+				Description: apiKeyDescription,
 			},
 			"api_server": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("TRUORA_API_SERVER", "https://api.identity.truora.com"),
-				Description: "The API server for the Truora API",
+				Optional:    true,
+				Description: apiServerDescription,
+				Deprecated:  apiServerDeprecation,
+			},
+			"endpoints": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: endpointsDescription,
+				Elem: &schema.Resource{
+					Schema: endpointsSchema(),
+				},
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: proxyURLDescription,
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: caCertPEMDescription,
+			},
+			"client_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: clientCertPEMDescription,
+			},
+			"client_key_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: clientKeyPEMDescription,
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: insecureSkipVerifyDescription,
+			},
+			"requests_per_second": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Description: requestsPerSecondDescription,
+			},
+			"burst": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: burstDescription,
+			},
+			"cache_flows": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: cacheFlowsDescription,
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"truora_flow": resourceFlow(),
+			"truora_api_key":                    resourceAPIKey(),
+			"truora_check_custom_type":          resourceCheckCustomType(),
+			"truora_continuous_check":           resourceContinuousCheck(),
+			"truora_flow":                       resourceFlow(),
+			"truora_flow_link":                  resourceFlowLink(),
+			"truora_webhook":                    resourceWebhook(),
+			"truora_whatsapp_outbound_template": resourceWhatsAppOutboundTemplate(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"truora_flow":          dataSourceFlow(),
-			"truora_flow_document": dataSourceFlowDocument(),
+			"truora_account":               dataSourceAccount(),
+			"truora_flow":                  dataSourceFlow(),
+			"truora_flow_document":         dataSourceFlowDocument(),
+			"truora_process":               dataSourceProcess(),
+			"truora_verification_document": dataSourceVerificationDocument(),
 		},
-		ConfigureContextFunc: providerConfigure,
 	}
+
+	for name, r := range p.ResourcesMap {
+		instrumentResource(name, r)
+	}
+
+	for name, r := range p.DataSourcesMap {
+		instrumentResource("data."+name, r)
+	}
+
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return providerConfigure(ctx, d, p.UserAgent(truora.DefaultUserAgent, Version))
+	}
+
+	return p
 }
 
-func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func providerConfigure(_ context.Context, d *schema.ResourceData, userAgent string) (interface{}, diag.Diagnostics) {
 	config := Config{
-		APIKey:    d.Get("api_key").(string),
-		APIServer: d.Get("api_server").(string),
+		APIKey:             d.Get("api_key").(string),
+		APIServer:          d.Get("api_server").(string),
+		UserAgent:          userAgent,
+		ProxyURL:           d.Get("proxy_url").(string),
+		CACertPEM:          d.Get("ca_cert_pem").(string),
+		ClientCertPEM:      d.Get("client_cert_pem").(string),
+		ClientKeyPEM:       d.Get("client_key_pem").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+		RequestsPerSecond:  d.Get("requests_per_second").(float64),
+		Burst:              d.Get("burst").(int),
+		CacheFlows:         d.Get("cache_flows").(bool),
+	}
+
+	if v, ok := d.GetOk("endpoints.0"); ok {
+		endpointsMap := v.(map[string]interface{})
+
+		config.Endpoints = truora.Endpoints{
+			Identity:    getStringFromMap(endpointsMap, "identity"),
+			Checks:      getStringFromMap(endpointsMap, "checks"),
+			Account:     getStringFromMap(endpointsMap, "account"),
+			Validations: getStringFromMap(endpointsMap, "validations"),
+			Connect:     getStringFromMap(endpointsMap, "connect"),
+			Web:         getStringFromMap(endpointsMap, "web"),
+		}
 	}
 
 	return config.Client()
 }
 
+func endpointsSchema() map[string]*schema.Schema {
+	endpoints := map[string]*schema.Schema{}
+	for name, description := range endpointDescriptions {
+		endpoints[name] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: description,
+		}
+	}
+
+	return endpoints
+}
+
 func (c *Config) Client() (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	var opts []truora.TruoraClientOption
@@ -65,14 +226,85 @@ func (c *Config) Client() (interface{}, diag.Diagnostics) {
 		opts = append(opts, truora.WithAPIServer(c.APIServer))
 	}
 
+	// endpoints come after api_server so endpoints.identity takes precedence
+	opts = append(opts, truora.WithEndpoints(c.Endpoints))
+
+	if c.UserAgent != "" {
+		opts = append(opts, truora.WithUserAgent(c.UserAgent))
+	}
+
+	transport, err := truora.NewTransport(truora.TransportOptions{
+		ProxyURL:           c.ProxyURL,
+		CACertPEM:          c.CACertPEM,
+		ClientCertPEM:      c.ClientCertPEM,
+		ClientKeyPEM:       c.ClientKeyPEM,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	opts = append(opts, truora.WithTransport(transport))
+
+	if c.RequestsPerSecond < 0 || c.Burst < 0 {
+		return nil, diag.Errorf("requests_per_second and burst can't be negative")
+	}
+
+	if c.RequestsPerSecond > 0 {
+		opts = append(opts, truora.WithRateLimit(c.RequestsPerSecond, c.Burst))
+	}
+
+	if c.CacheFlows {
+		opts = append(opts, truora.WithFlowCache())
+	}
+
 	rc, err := truora.NewClient(opts...)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 
+	diags = append(diags, apiKeyWarnings(rc, time.Now())...)
+
 	return rc, diags
 }
 
+// apiKeyWarnings reports keys that will stop working soon or that can't
+// manage flows. Keys that can't be decoded are left to fail on the API.
+func apiKeyWarnings(client *truora.TruoraClient, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	claims, err := client.APIKeyClaims()
+	if err != nil {
+		return diags
+	}
+
+	switch {
+	case claims.ExpiresAt == nil:
+	case !claims.ExpiresAt.After(now):
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Truora API key has expired",
+			Detail:   fmt.Sprintf("The API key %q expired at %s, requests to the Truora API will fail.", claims.KeyName, claims.ExpiresAt.Format(time.RFC3339)),
+		})
+	case claims.ExpiresAt.Sub(now) < apiKeyExpirationWarningWindow:
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Truora API key is close to expiring",
+			Detail:   fmt.Sprintf("The API key %q expires at %s, rotate it before it stops working.", claims.KeyName, claims.ExpiresAt.Format(time.RFC3339)),
+		})
+	}
+
+	if len(claims.Grants) > 0 && !claims.HasGrant(truora.DigitalIdentityGrant) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Truora API key can't manage flows",
+			Detail:   fmt.Sprintf("The API key %q doesn't have the %s grant, flow resources and data sources will fail.", claims.KeyName, truora.DigitalIdentityGrant),
+		})
+	}
+
+	return diags
+}
+
 func mergeSchemaMaps(maps ...map[string]*schema.Schema) map[string]*schema.Schema {
 	result := map[string]*schema.Schema{}
 	for _, m := range maps {
@@ -83,21 +315,6 @@ func mergeSchemaMaps(maps ...map[string]*schema.Schema) map[string]*schema.Schem
 	return result
 }
 
-func timeFromRFC3339(s string) *time.Time {
-	t, err := time.Parse(time.RFC3339, s)
-	if err != nil {
-		return nil
-	}
-	return &t
-}
-
-func getBoolFromMap(data map[string]interface{}, key string) bool {
-	if v, ok := data[key]; ok {
-		return v.(bool)
-	}
-	return false
-}
-
 func getStringFromMap(data map[string]interface{}, key string) string {
 	if v, ok := data[key]; ok {
 		return v.(string)
@@ -105,20 +322,6 @@ func getStringFromMap(data map[string]interface{}, key string) string {
 	return ""
 }
 
-func getTimeFromMap(data map[string]interface{}, key string) *time.Time {
-	if v, ok := data[key]; ok {
-		return timeFromRFC3339(v.(string))
-	}
-	return nil
-}
-
-func getInt64FromMap(data map[string]interface{}, key string) int64 {
-	if v, ok := data[key]; ok {
-		return int64(v.(int))
-	}
-	return 0
-}
-
 func parseStringArray(data map[string]interface{}, key string) []string {
 	if v, ok := data[key]; ok {
 		stringArray := make([]string, 0)
@@ -141,3 +344,35 @@ func getMapFromSingleElementList(data map[string]interface{}, key string) map[st
 	}
 	return nil
 }
+
+// canonicalJSON re-encodes a JSON document with sorted keys and a stable two
+// space indentation, so equal documents always render to the same bytes.
+func canonicalJSON(document []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(value, "", "  ")
+}
+
+// apiDateLayout is how the Truora API formats dates, always in UTC
+const apiDateLayout = "2006-01-02T15:04:05.000Z"
+
+// formatOptionalTime formats t like the API does, or returns an empty string
+// when t is nil
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(apiDateLayout)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
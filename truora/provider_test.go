@@ -0,0 +1,184 @@
+package truora
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+// testAccProtoV5ProviderFactories serves the provider the same way main does
+var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"truora": func() (tfprotov5.ProviderServer, error) {
+		muxServer, err := tf5muxserver.NewMuxServer(context.Background(), ProviderServers()...)
+		if err != nil {
+			return nil, err
+		}
+
+		return muxServer.ProviderServer(), nil
+	},
+}
+
+// testAccFakeServer starts a fake Truora API and points the provider to it
+// through the environment, so test configurations need no provider block.
+func testAccFakeServer(t *testing.T, opts ...truoratest.ServerOption) *truoratest.Server {
+	server := truoratest.NewServer(opts...)
+	t.Cleanup(server.Close)
+
+	t.Setenv(truora.APIKeyEnvironmentVariableName, truoratest.APIKey)
+	t.Setenv(truora.IdentityAPIServerEnvironmentVariableName, server.URL)
+	t.Setenv(truora.ChecksAPIServerEnvironmentVariableName, server.Endpoints().Checks)
+	t.Setenv(truora.AccountAPIServerEnvironmentVariableName, server.URL)
+	t.Setenv(truora.ValidationsAPIServerEnvironmentVariableName, server.URL)
+	t.Setenv(truora.ConnectAPIServerEnvironmentVariableName, server.URL)
+
+	return server
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProviderMuxServer(t *testing.T) {
+	server, err := testAccProtoV5ProviderFactories["truora"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range resp.Diagnostics {
+		t.Errorf("%s: %s", d.Summary, d.Detail)
+	}
+}
+
+func TestFrameworkProviderReusesSDKClient(t *testing.T) {
+	testAccFakeServer(t)
+
+	ctx := context.Background()
+
+	sdkProvider := Provider()
+	if diags := sdkProvider.Configure(ctx, terraform.NewResourceConfigRaw(nil)); diags.HasError() {
+		t.Fatalf("configuring the SDK provider: %v", diags)
+	}
+
+	p := &frameworkProvider{sdkProvider: sdkProvider}
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)},
+	}, &resp)
+
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("%s: %s", d.Summary(), d.Detail())
+	}
+
+	if resp.EphemeralResourceData != sdkProvider.Meta() {
+		t.Fatalf("expected the client of the SDK provider, got %p", resp.EphemeralResourceData)
+	}
+}
+
+func TestAPIKeyWarnings(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	apiKey := func(claims string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256"}`)) + "." +
+			base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+	}
+
+	cases := []struct {
+		name     string
+		apiKey   string
+		warnings []string
+	}{
+		{
+			name:   "malformed key",
+			apiKey: "plain-key",
+		},
+		{
+			name:   "missing exp",
+			apiKey: apiKey(`{"key_name":"ci","grant":"digital-identity"}`),
+		},
+		{
+			name:   "valid for long",
+			apiKey: apiKey(fmt.Sprintf(`{"key_name":"ci","grant":"digital-identity","exp":%d}`, now.Add(90*24*time.Hour).Unix())),
+		},
+		{
+			name:     "about to expire",
+			apiKey:   apiKey(fmt.Sprintf(`{"key_name":"ci","grant":"digital-identity","exp":%d}`, now.Add(24*time.Hour).Unix())),
+			warnings: []string{"Truora API key is close to expiring"},
+		},
+		{
+			name:     "expired",
+			apiKey:   apiKey(fmt.Sprintf(`{"key_name":"ci","grant":"digital-identity","exp":%d}`, now.Add(-time.Hour).Unix())),
+			warnings: []string{"Truora API key has expired"},
+		},
+		{
+			name:   "missing grant claim",
+			apiKey: apiKey(`{"key_name":"ci"}`),
+		},
+		{
+			name:     "without the digital identity grant",
+			apiKey:   apiKey(`{"key_name":"ci","grant":["checks"]}`),
+			warnings: []string{"Truora API key can't manage flows"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var summaries []string
+			for _, d := range apiKeyWarnings(&truora.TruoraClient{APIKey: tc.apiKey}, now) {
+				if d.Severity != diag.Warning {
+					t.Errorf("expected a warning, got %v", d)
+				}
+
+				summaries = append(summaries, d.Summary)
+			}
+
+			if !reflect.DeepEqual(summaries, tc.warnings) {
+				t.Fatalf("expected %v, got %v", tc.warnings, summaries)
+			}
+		})
+	}
+}
+
+func TestFormatOptionalTime(t *testing.T) {
+	bogota := time.FixedZone("COT", -5*60*60)
+	local := time.Date(2024, 3, 1, 21, 30, 0, 123456789, bogota)
+
+	if actual := formatOptionalTime(&local); actual != "2024-03-02T02:30:00.123Z" {
+		t.Fatalf("expected the time in UTC, got %s", actual)
+	}
+
+	if actual := formatOptionalTime(nil); actual != "" {
+		t.Fatalf("expected an empty string for nil, got %s", actual)
+	}
+}
@@ -0,0 +1,205 @@
+package truora
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+var apiKeyTypes = []string{"backend", "web", "sdk"}
+
+// defaultAPIKeyNamePrefix is used when neither key_name nor name_prefix is set
+const defaultAPIKeyNamePrefix = "terraform-"
+
+// resourceAPIKey has no update, every argument forces a new key. Changing
+// any value in keepers rotates the key. Key names are unique, so unless
+// key_name is set the new key can be created before the old one is deleted
+// with create_before_destroy.
+func resourceAPIKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAPIKeyCreate,
+		ReadContext:   resourceAPIKeyRead,
+		DeleteContext: resourceAPIKeyDelete,
+		Schema: map[string]*schema.Schema{
+			"key_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name_prefix"},
+			},
+			"name_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"key_name"},
+			},
+			"key_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(apiKeyTypes, false)),
+			},
+			"grants": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"flow_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"expiration": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+				DiffSuppressFunc: suppressEquivalentTime,
+			},
+			"keepers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"api_key": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceAPIKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	request := &truora.APIKeyRequest{
+		KeyName: apiKeyName(d),
+		KeyType: d.Get("key_type").(string),
+		FlowID:  d.Get("flow_id").(string),
+	}
+
+	for _, grant := range d.Get("grants").(*schema.Set).List() {
+		request.Grants = append(request.Grants, grant.(string))
+	}
+
+	if v, ok := d.GetOk("expiration"); ok {
+		expiration, err := time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		request.Expiration = &expiration
+	}
+
+	resp, err := client.CreateAPIKey(ctx, request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(request.KeyName)
+
+	if err = d.Set("api_key", resp.Key); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAPIKeyRead(ctx, d, m)
+}
+
+func resourceAPIKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	apiKey, err := client.GetAPIKey(ctx, d.Id())
+	if errors.Is(err, truora.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("key_name", apiKey.KeyName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("key_type", apiKey.KeyType); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("grants", apiKey.Grants); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("flow_id", apiKey.FlowID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	expiration := ""
+	if apiKey.Expiration != nil {
+		expiration = apiKey.Expiration.Format(time.RFC3339)
+	}
+
+	if err = d.Set("expiration", expiration); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// apiKeyName returns key_name when it is set, otherwise a unique name
+// starting with name_prefix
+func apiKeyName(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("key_name"); ok {
+		return v.(string)
+	}
+
+	if v, ok := d.GetOk("name_prefix"); ok {
+		return id.PrefixedUniqueId(v.(string))
+	}
+
+	return id.PrefixedUniqueId(defaultAPIKeyNamePrefix)
+}
+
+// suppressEquivalentTime ignores differences in the offset or format of
+// times that are the same instant
+func suppressEquivalentTime(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, oldValue)
+	if err != nil {
+		return false
+	}
+
+	newTime, err := time.Parse(time.RFC3339, newValue)
+	if err != nil {
+		return false
+	}
+
+	return oldTime.Equal(newTime)
+}
+
+func resourceAPIKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	err := client.DeleteAPIKey(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
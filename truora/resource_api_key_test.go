@@ -0,0 +1,145 @@
+package truora
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+func TestAccResourceAPIKey_rotation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	var first string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckAPIKeyDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAPIKeyRotationConfig("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("truora_api_key.test", "key_name", regexp.MustCompile(`^ci-`)),
+					resource.TestCheckResourceAttrPair("truora_api_key.test", "id", "truora_api_key.test", "key_name"),
+					resource.TestCheckResourceAttrSet("truora_api_key.test", "api_key"),
+					resource.TestCheckResourceAttr("truora_api_key.test", "expiration", ""),
+					func(s *terraform.State) error {
+						first = s.RootModule().Resources["truora_api_key.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// the new key gets another name, so it is created before the
+				// old one is deleted
+				Config: testAccResourceAPIKeyRotationConfig("2"),
+				Check: func(s *terraform.State) error {
+					second := s.RootModule().Resources["truora_api_key.test"].Primary.ID
+					if second == first {
+						return fmt.Errorf("expected the key %s to be rotated", first)
+					}
+
+					if keys := server.APIKeys(); !slices.Equal(keys, []string{second}) {
+						return fmt.Errorf("expected only the key %s, got %v", second, keys)
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceAPIKey_defaultNamePrefix(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckAPIKeyDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "truora_api_key" "test" {
+  key_type = "backend"
+  grants   = ["all"]
+}
+`,
+				Check: resource.TestMatchResourceAttr("truora_api_key.test", "key_name", regexp.MustCompile(`^terraform-`)),
+			},
+		},
+	})
+}
+
+func TestAccResourceAPIKey_expiration(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckAPIKeyDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				// the API returns the expiration in UTC, which is the same time
+				Config: testAccResourceAPIKeyExpirationConfig("2030-01-02T15:04:05-05:00"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("truora_api_key.test", "key_name", "ci-expiring"),
+					resource.TestCheckResourceAttr("truora_api_key.test", "expiration", "2030-01-02T20:04:05Z"),
+				),
+			},
+			{
+				Config:             testAccResourceAPIKeyExpirationConfig("2030-01-03T15:04:05-05:00"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAPIKeyDestroy(server *truoratest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "truora_api_key" {
+				continue
+			}
+
+			if slices.Contains(server.APIKeys(), rs.Primary.ID) {
+				return fmt.Errorf("API key %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceAPIKeyRotationConfig(version string) string {
+	return fmt.Sprintf(`
+resource "truora_api_key" "test" {
+  name_prefix = "ci-"
+  key_type    = "backend"
+  grants      = ["all"]
+
+  keepers = {
+    version = %q
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`, version)
+}
+
+func testAccResourceAPIKeyExpirationConfig(expiration string) string {
+	return fmt.Sprintf(`
+resource "truora_api_key" "test" {
+  key_name   = "ci-expiring"
+  key_type   = "backend"
+  grants     = ["all"]
+  expiration = %q
+}
+`, expiration)
+}
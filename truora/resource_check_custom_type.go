@@ -0,0 +1,188 @@
+package truora
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+var checkCountries = []string{"ALL", "BR", "CL", "CO", "CR", "EC", "MX", "PE"}
+
+func resourceCheckCustomType() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCheckCustomTypeCreate,
+		ReadContext:   resourceCheckCustomTypeRead,
+		UpdateContext: resourceCheckCustomTypeUpdate,
+		DeleteContext: resourceCheckCustomTypeDelete,
+		Schema: map[string]*schema.Schema{
+			"country": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(checkCountries, false)),
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			"dataset_weight": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dataset": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"weight": {
+							Type:             schema.TypeFloat,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.FloatBetween(0, 1)),
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCheckCustomTypeImport,
+		},
+	}
+}
+
+// check custom types are identified by country and type, the resource ID
+// joins both as COUNTRY/type
+func checkCustomTypeID(country, customType string) string {
+	return fmt.Sprintf("%s/%s", country, customType)
+}
+
+func parseCheckCustomTypeID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected check custom type ID %q, expected COUNTRY/type", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func checkCustomTypeFromResourceData(d *schema.ResourceData) *truora.CheckCustomType {
+	checkCustomType := &truora.CheckCustomType{
+		Country: d.Get("country").(string),
+		Type:    d.Get("type").(string),
+	}
+
+	for _, v := range d.Get("dataset_weight").([]interface{}) {
+		datasetWeightMap := v.(map[string]interface{})
+
+		checkCustomType.DatasetWeights = append(checkCustomType.DatasetWeights, &truora.DatasetWeight{
+			Dataset: getStringFromMap(datasetWeightMap, "dataset"),
+			Weight:  datasetWeightMap["weight"].(float64),
+		})
+	}
+
+	return checkCustomType
+}
+
+func resourceCheckCustomTypeImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	country, customType, err := parseCheckCustomTypeID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("country", country)
+	d.Set("type", customType)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceCheckCustomTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	checkCustomType := checkCustomTypeFromResourceData(d)
+
+	_, err := client.CreateCheckCustomType(ctx, checkCustomType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(checkCustomTypeID(checkCustomType.Country, checkCustomType.Type))
+
+	return resourceCheckCustomTypeRead(ctx, d, m)
+}
+
+func resourceCheckCustomTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	country, customType, err := parseCheckCustomTypeID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	checkCustomType, err := client.GetCheckCustomType(ctx, country, customType)
+	if errors.Is(err, truora.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("country", checkCustomType.Country); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("type", checkCustomType.Type); err != nil {
+		return diag.FromErr(err)
+	}
+
+	datasetWeights := make([]interface{}, len(checkCustomType.DatasetWeights))
+	for i, datasetWeight := range checkCustomType.DatasetWeights {
+		datasetWeights[i] = map[string]interface{}{
+			"dataset": datasetWeight.Dataset,
+			"weight":  datasetWeight.Weight,
+		}
+	}
+
+	if err = d.Set("dataset_weight", datasetWeights); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCheckCustomTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	_, err := client.UpdateCheckCustomType(ctx, checkCustomTypeFromResourceData(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceCheckCustomTypeRead(ctx, d, m)
+}
+
+func resourceCheckCustomTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	country, customType, err := parseCheckCustomTypeID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteCheckCustomType(ctx, country, customType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
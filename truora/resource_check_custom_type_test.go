@@ -0,0 +1,129 @@
+package truora
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+func TestAccResourceCheckCustomType_basic(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckCheckCustomTypeDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceCheckCustomTypeConfig(0.7),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("truora_check_custom_type.test", "id", "CO/onboarding"),
+					resource.TestCheckResourceAttr("truora_check_custom_type.test", "dataset_weight.#", "2"),
+					testAccCheckCheckCustomTypeWeights(server, []*truora.DatasetWeight{
+						{Dataset: "criminal_record", Weight: 0.7},
+						{Dataset: "legal_background", Weight: 0.3},
+					}),
+				),
+			},
+			{
+				Config: testAccResourceCheckCustomTypeConfig(0.5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("truora_check_custom_type.test", "dataset_weight.0.weight", "0.5"),
+					testAccCheckCheckCustomTypeWeights(server, []*truora.DatasetWeight{
+						{Dataset: "criminal_record", Weight: 0.5},
+						{Dataset: "legal_background", Weight: 0.3},
+					}),
+				),
+			},
+			{
+				ResourceName:      "truora_check_custom_type.test",
+				ImportState:       true,
+				ImportStateId:     "CO/onboarding",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceCheckCustomType_invalidImportID(t *testing.T) {
+	testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccResourceCheckCustomTypeConfig(0.7),
+				ResourceName:  "truora_check_custom_type.test",
+				ImportState:   true,
+				ImportStateId: "onboarding",
+				ExpectError:   regexp.MustCompile(`expected COUNTRY/type`),
+			},
+		},
+	})
+}
+
+func testAccCheckCheckCustomTypeWeights(server *truoratest.Server, expected []*truora.DatasetWeight) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		checkCustomType, ok := server.CheckCustomType("CO", "onboarding")
+		if !ok {
+			return fmt.Errorf("custom type CO/onboarding not found")
+		}
+
+		if len(checkCustomType.DatasetWeights) != len(expected) {
+			return fmt.Errorf("expected %d dataset weights, got %d", len(expected), len(checkCustomType.DatasetWeights))
+		}
+
+		for i, datasetWeight := range checkCustomType.DatasetWeights {
+			if *datasetWeight != *expected[i] {
+				return fmt.Errorf("expected dataset weight %d to be %+v, got %+v", i, *expected[i], *datasetWeight)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCheckCustomTypeDestroy(server *truoratest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "truora_check_custom_type" {
+				continue
+			}
+
+			country, customType, err := parseCheckCustomTypeID(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			if _, ok := server.CheckCustomType(country, customType); ok {
+				return fmt.Errorf("custom type %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceCheckCustomTypeConfig(criminalRecordWeight float64) string {
+	return fmt.Sprintf(`
+resource "truora_check_custom_type" "test" {
+  country = "CO"
+  type    = "onboarding"
+
+  dataset_weight {
+    dataset = "criminal_record"
+    weight  = %g
+  }
+
+  dataset_weight {
+    dataset = "legal_background"
+    weight  = 0.3
+  }
+}
+`, criminalRecordWeight)
+}
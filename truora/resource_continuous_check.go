@@ -0,0 +1,163 @@
+package truora
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+var (
+	continuousCheckTypes       = []string{"person", "vehicle", "company"}
+	continuousCheckFrequencies = []string{"daily", "weekly", "biweekly", "monthly"}
+)
+
+func resourceContinuousCheck() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceContinuousCheckCreate,
+		ReadContext:   resourceContinuousCheckRead,
+		UpdateContext: resourceContinuousCheckUpdate,
+		DeleteContext: resourceContinuousCheckDelete,
+		Schema: map[string]*schema.Schema{
+			"continuous_check_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"national_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"country": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(checkCountries, false)),
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(continuousCheckTypes, false)),
+			},
+			"frequency": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(continuousCheckFrequencies, false)),
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"creation_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"next_run_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceContinuousCheckCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	resp, err := client.CreateContinuousCheck(ctx, &truora.ContinuousCheck{
+		NationalID: d.Get("national_id").(string),
+		Country:    d.Get("country").(string),
+		Type:       d.Get("type").(string),
+		Frequency:  d.Get("frequency").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.ContinuousCheckID)
+
+	return resourceContinuousCheckRead(ctx, d, m)
+}
+
+func resourceContinuousCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	continuousCheck, err := client.GetContinuousCheck(ctx, d.Id())
+	if errors.Is(err, truora.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// canceled checks are kept by the API but are gone for terraform
+	if continuousCheck.Status == truora.ContinuousCheckCanceledStatus {
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("continuous_check_id", continuousCheck.ContinuousCheckID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("national_id", continuousCheck.NationalID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("country", continuousCheck.Country); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("type", continuousCheck.Type); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("frequency", continuousCheck.Frequency); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("status", continuousCheck.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("creation_date", formatOptionalTime(continuousCheck.CreationDate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("next_run_date", formatOptionalTime(continuousCheck.NextRunDate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceContinuousCheckUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	_, err := client.UpdateContinuousCheckFrequency(ctx, d.Id(), d.Get("frequency").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceContinuousCheckRead(ctx, d, m)
+}
+
+func resourceContinuousCheckDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	err := client.CancelContinuousCheck(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package truora
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+// apiDatePattern matches dates formatted with apiDateLayout
+var apiDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{3}Z$`)
+
+func TestAccResourceContinuousCheck_basic(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	var continuousCheckID string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckContinuousCheckDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceContinuousCheckConfig("weekly"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("truora_continuous_check.test", "id", "truora_continuous_check.test", "continuous_check_id"),
+					resource.TestCheckResourceAttr("truora_continuous_check.test", "status", "active"),
+					resource.TestMatchResourceAttr("truora_continuous_check.test", "creation_date", apiDatePattern),
+					resource.TestMatchResourceAttr("truora_continuous_check.test", "next_run_date", apiDatePattern),
+					func(s *terraform.State) error {
+						continuousCheckID = s.RootModule().Resources["truora_continuous_check.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// the frequency is updated in place
+				Config: testAccResourceContinuousCheckConfig("monthly"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("truora_continuous_check.test", "frequency", "monthly"),
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources["truora_continuous_check.test"]
+						if rs.Primary.ID != continuousCheckID {
+							return fmt.Errorf("expected continuous check %s to be updated, got %s", continuousCheckID, rs.Primary.ID)
+						}
+
+						continuousCheck, ok := server.ContinuousCheck(rs.Primary.ID)
+						if !ok || continuousCheck.Frequency != "monthly" {
+							return fmt.Errorf("expected the API to run the check monthly, got %+v", continuousCheck)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "truora_continuous_check.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceContinuousCheck_canceled(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	var continuousCheckID string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckContinuousCheckDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceContinuousCheckConfig("daily"),
+				Check: func(s *terraform.State) error {
+					continuousCheckID = s.RootModule().Resources["truora_continuous_check.test"].Primary.ID
+					return nil
+				},
+			},
+			{
+				// canceled outside terraform, the check is created again
+				PreConfig: func() {
+					continuousCheck, ok := server.ContinuousCheck(continuousCheckID)
+					if !ok {
+						t.Fatalf("continuous check %s not found", continuousCheckID)
+					}
+
+					continuousCheck.Status = truora.ContinuousCheckCanceledStatus
+					server.PutContinuousCheck(continuousCheck)
+				},
+				Config:             testAccResourceContinuousCheckConfig("daily"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCheckContinuousCheckDestroy(server *truoratest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "truora_continuous_check" {
+				continue
+			}
+
+			continuousCheck, ok := server.ContinuousCheck(rs.Primary.ID)
+			if ok && continuousCheck.Status != truora.ContinuousCheckCanceledStatus {
+				return fmt.Errorf("continuous check %s is still %s", rs.Primary.ID, continuousCheck.Status)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceContinuousCheckConfig(frequency string) string {
+	return fmt.Sprintf(`
+resource "truora_continuous_check" "test" {
+  national_id = "1020304050"
+  country     = "CO"
+  type        = "person"
+  frequency   = %q
+}
+`, frequency)
+}
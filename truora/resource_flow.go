@@ -3,6 +3,7 @@ package truora
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -74,7 +75,12 @@ func resourceFlowRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	flowID := d.Id()
 
-	flow, err := client.GetFlow(flowID)
+	flow, err := client.GetFlow(ctx, flowID)
+	if errors.Is(err, truora.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -83,11 +89,11 @@ func resourceFlowRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		return diag.FromErr(err)
 	}
 
-	if err = d.Set("creation_date", flow.CreationDate.Format("2006-01-02T15:04:05.000Z")); err != nil {
+	if err = d.Set("creation_date", formatOptionalTime(flow.CreationDate)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err = d.Set("update_date", flow.UpdateDate.Format("2006-01-02T15:04:05.000Z")); err != nil {
+	if err = d.Set("update_date", formatOptionalTime(flow.UpdateDate)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -128,7 +134,7 @@ func resourceFlowDelete(ctx context.Context, d *schema.ResourceData, m interface
 
 	flowID := d.Id()
 
-	err := client.DeleteFlow(flowID)
+	err := client.DeleteFlow(ctx, flowID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
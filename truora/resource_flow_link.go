@@ -0,0 +1,147 @@
+package truora
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+// resourceFlowLink mints a web integration token for a flow and keeps it in
+// the state, so the process URL can be a root output. The API key behind the
+// token is deleted on destroy, and a link whose key expired or was deleted is
+// removed on refresh so the next apply mints a fresh one. Use the
+// truora_web_token ephemeral resource when the link doesn't need to outlive
+// the run.
+func resourceFlowLink() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFlowLinkCreate,
+		ReadContext:   resourceFlowLinkRead,
+		DeleteContext: resourceFlowLinkDelete,
+		Schema: map[string]*schema.Schema{
+			"flow_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"country": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "ALL",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			"redirect_url": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+			},
+			"expires_in": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"key_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expiration": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"integration_token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"process_url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceFlowLinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	token, err := client.CreateWebIntegrationToken(ctx, &truora.WebIntegrationTokenRequest{
+		FlowID:      d.Get("flow_id").(string),
+		Country:     d.Get("country").(string),
+		RedirectURL: d.Get("redirect_url").(string),
+		ExpiresIn:   int64(d.Get("expires_in").(int)),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(token.KeyName)
+
+	if err = d.Set("key_name", token.KeyName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("expiration", formatOptionalTime(token.Expiration)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("integration_token", token.Key); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("process_url", client.ProcessURL(token.Key)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceFlowLinkRead only checks the key still works, the token itself is
+// never returned again
+func resourceFlowLinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	apiKey, err := client.GetAPIKey(ctx, d.Id())
+	if errors.Is(err, truora.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if apiKey.Expiration != nil && !apiKey.Expiration.After(time.Now()) {
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("key_name", apiKey.KeyName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("expiration", formatOptionalTime(apiKey.Expiration)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceFlowLinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	err := client.DeleteAPIKey(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package truora
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+func TestAccResourceFlowLink_basic(t *testing.T) {
+	server := testAccFakeServer(t)
+	t.Setenv(truora.WebServerEnvironmentVariableName, "https://identity.example.com")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckFlowLinkDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceFlowLinkConfig(3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("truora_flow_link.test", "integration_token"),
+					resource.TestCheckResourceAttrSet("truora_flow_link.test", "expiration"),
+					resource.TestCheckResourceAttrPair("truora_flow_link.test", "id", "truora_flow_link.test", "key_name"),
+					resource.TestMatchResourceAttr("truora_flow_link.test", "process_url", regexp.MustCompile(`^https://identity\.example\.com/\?token=KEY`)),
+					testAccCheckFlowLinkExists(server),
+				),
+			},
+			{
+				// the output outlives the run, unlike truora_web_token
+				Config:   testAccResourceFlowLinkConfig(3600),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceFlowLink_expired(t *testing.T) {
+	testAccFakeServer(t, truoratest.WithClock(func() time.Time {
+		return time.Now().Add(-time.Hour)
+	}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// the key expires before the refresh after apply, so the link
+				// is planned to be minted again
+				Config:             testAccResourceFlowLinkConfig(60),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCheckFlowLinkExists(server *truoratest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources["truora_flow_link.test"]
+
+		if !slices.Contains(server.APIKeys(), rs.Primary.ID) {
+			return fmt.Errorf("API key %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckFlowLinkDestroy(server *truoratest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "truora_flow_link" {
+				continue
+			}
+
+			if slices.Contains(server.APIKeys(), rs.Primary.ID) {
+				return fmt.Errorf("API key %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceFlowLinkConfig(expiresIn int) string {
+	return fmt.Sprintf(`
+resource "truora_flow_link" "test" {
+  flow_id      = "IPFtest"
+  redirect_url = "https://example.com/done"
+  expires_in   = %d
+}
+`, expiresIn)
+}
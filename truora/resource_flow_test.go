@@ -0,0 +1,129 @@
+package truora
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+func TestAccResourceFlow_basic(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckFlowDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceFlowConfig("acceptance flow"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("truora_flow.test", "flow_id"),
+					resource.TestCheckResourceAttr("truora_flow.test", "name", "acceptance flow"),
+					resource.TestCheckResourceAttr("truora_flow.test", "version", "1"),
+				),
+			},
+			{
+				Config: testAccResourceFlowConfig("acceptance flow updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("truora_flow.test", "name", "acceptance flow updated"),
+					resource.TestCheckResourceAttr("truora_flow.test", "version", "2"),
+				),
+			},
+			{
+				ResourceName:            "truora_flow.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"document"},
+			},
+		},
+	})
+}
+
+func TestAccResourceFlow_disappears(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckFlowDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceFlowConfig("acceptance flow"),
+				Check: func(s *terraform.State) error {
+					client, err := server.NewClient()
+					if err != nil {
+						return err
+					}
+
+					for _, flow := range server.Flows() {
+						if err := client.DeleteFlow(context.Background(), flow.FlowID); err != nil {
+							return err
+						}
+					}
+
+					return nil
+				},
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceFlow_serverError(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	// every attempt fails, a single failure is retried by CreateFlow
+	server.InjectFault(truoratest.Fault{
+		Method:     http.MethodPost,
+		PathPrefix: "/v1/flows",
+		StatusCode: http.StatusInternalServerError,
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckFlowDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceFlowConfig("acceptance flow"),
+				ExpectError: regexp.MustCompile("error creating flow: 500"),
+			},
+		},
+	})
+}
+
+func testAccCheckFlowDestroy(server *truoratest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "truora_flow" {
+				continue
+			}
+
+			if _, ok := server.Flow(rs.Primary.ID); ok {
+				return fmt.Errorf("flow %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceFlowConfig(name string) string {
+	return fmt.Sprintf(`
+resource "truora_flow" "test" {
+  document = jsonencode({
+    name = %q
+    type = "permanent"
+    identity_verifications = [
+      {
+        name = "email_verification"
+      },
+    ]
+  })
+}
+`, name)
+}
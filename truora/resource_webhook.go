@@ -0,0 +1,235 @@
+package truora
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/webhooks"
+)
+
+var (
+	webhookAuthTypes = []string{"none", "basic", "bearer"}
+	webhookStatuses  = []string{"enabled", "disabled"}
+)
+
+func resourceWebhook() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWebhookCreate,
+		ReadContext:   resourceWebhookRead,
+		UpdateContext: resourceWebhookUpdate,
+		DeleteContext: resourceWebhookDelete,
+		Schema: map[string]*schema.Schema{
+			"hook_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"event_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(webhooks.EventTypes, false)),
+			},
+			"event_action": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			"url": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+			},
+			"auth_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "none",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(webhookAuthTypes, false)),
+			},
+			"username": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"token"},
+			},
+			// password and token are never returned by the API, so they are
+			// write-only and stay out of the plan and state. Changes to them
+			// can't be detected, credentials_version triggers the update that
+			// sends them again.
+			"password": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				RequiredWith:  []string{"username"},
+				ConflictsWith: []string{"token"},
+			},
+			"token": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"credentials_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"status": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "enabled",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(webhookStatuses, false)),
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func hookFromResourceData(d *schema.ResourceData) (*truora.Hook, diag.Diagnostics) {
+	password, diags := getWriteOnlyString(d, "password")
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	token, diags := getWriteOnlyString(d, "token")
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &truora.Hook{
+		EventType:   d.Get("event_type").(string),
+		EventAction: d.Get("event_action").(string),
+		URL:         d.Get("url").(string),
+		AuthType:    d.Get("auth_type").(string),
+		Username:    d.Get("username").(string),
+		Password:    password,
+		Token:       token,
+		Status:      d.Get("status").(string),
+	}, nil
+}
+
+// getWriteOnlyString reads a write-only attribute from the configuration,
+// d.Get always returns the zero value for them
+func getWriteOnlyString(d *schema.ResourceData, key string) (string, diag.Diagnostics) {
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() {
+		return "", diags
+	}
+
+	if v.IsNull() || !v.IsKnown() || !v.Type().Equals(cty.String) {
+		return "", nil
+	}
+
+	return v.AsString(), nil
+}
+
+func resourceWebhookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	hook, diags := hookFromResourceData(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	resp, err := client.CreateHook(ctx, hook)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.HookID)
+
+	return resourceWebhookRead(ctx, d, m)
+}
+
+func resourceWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	hook, err := client.GetHook(ctx, d.Id())
+	if errors.Is(err, truora.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("hook_id", hook.HookID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("event_type", hook.EventType); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("event_action", hook.EventAction); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("url", hook.URL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// hooks without authentication come back with an empty auth type
+	authType := hook.AuthType
+	if authType == "" {
+		authType = "none"
+	}
+
+	if err = d.Set("auth_type", authType); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("username", hook.Username); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the API doesn't always return the status, the configured one is kept
+	// so it doesn't show a diff against the default
+	status := hook.Status
+	if status == "" {
+		status = d.Get("status").(string)
+	}
+
+	if status == "" {
+		status = "enabled"
+	}
+
+	if err = d.Set("status", status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceWebhookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	hook, diags := hookFromResourceData(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	_, err := client.UpdateHook(ctx, d.Id(), hook)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceWebhookRead(ctx, d, m)
+}
+
+func resourceWebhookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	err := client.DeleteHook(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
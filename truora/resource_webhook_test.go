@@ -0,0 +1,131 @@
+package truora
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+func TestAccResourceWebhook_credentials(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		// write-only attributes need Terraform 1.11
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		CheckDestroy: testAccCheckWebhookDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceWebhookConfig("first", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("truora_webhook.test", "hook_id"),
+					resource.TestCheckNoResourceAttr("truora_webhook.test", "token"),
+					resource.TestCheckResourceAttr("truora_webhook.test", "status", "enabled"),
+					testAccCheckWebhookToken(server, "first"),
+				),
+			},
+			{
+				// token changes alone are invisible, nothing is sent
+				Config:   testAccResourceWebhookConfig("second", 1),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccResourceWebhookConfig("second", 2),
+				Check:  testAccCheckWebhookToken(server, "second"),
+			},
+			{
+				ResourceName:            "truora_webhook.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"credentials_version"},
+			},
+		},
+	})
+}
+
+func TestAccResourceWebhook_statusNotReturned(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		CheckDestroy: testAccCheckWebhookDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceWebhookConfig("first", 1),
+				Check: func(s *terraform.State) error {
+					rs := s.RootModule().Resources["truora_webhook.test"]
+
+					hook, ok := server.Hook(rs.Primary.ID)
+					if !ok {
+						return fmt.Errorf("hook %s not found", rs.Primary.ID)
+					}
+
+					hook.Status = ""
+					server.PutHook(hook)
+
+					return nil
+				},
+			},
+			{
+				Config:   testAccResourceWebhookConfig("first", 1),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCheckWebhookToken(server *truoratest.Server, token string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources["truora_webhook.test"]
+
+		hook, ok := server.Hook(rs.Primary.ID)
+		if !ok {
+			return fmt.Errorf("hook %s not found", rs.Primary.ID)
+		}
+
+		if hook.Token != token {
+			return fmt.Errorf("expected token %q, got %q", token, hook.Token)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckWebhookDestroy(server *truoratest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "truora_webhook" {
+				continue
+			}
+
+			if _, ok := server.Hook(rs.Primary.ID); ok {
+				return fmt.Errorf("hook %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceWebhookConfig(token string, credentialsVersion int) string {
+	return fmt.Sprintf(`
+resource "truora_webhook" "test" {
+  event_type          = "identity_process"
+  event_action        = "succeeded"
+  url                 = "https://example.com/hooks"
+  auth_type           = "bearer"
+  token               = %q
+  credentials_version = %d
+}
+`, token, credentialsVersion)
+}
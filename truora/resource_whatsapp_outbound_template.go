@@ -0,0 +1,235 @@
+package truora
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	truora "terraform-provider-truora/truora/client"
+)
+
+// outboundApprovalPollInterval is how often templates are polled while
+// waiting for approval
+var outboundApprovalPollInterval = 10 * time.Second
+
+func resourceWhatsAppOutboundTemplate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWhatsAppOutboundTemplateCreate,
+		ReadContext:   resourceWhatsAppOutboundTemplateRead,
+		UpdateContext: resourceWhatsAppOutboundTemplateUpdate,
+		DeleteContext: resourceWhatsAppOutboundTemplateDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"outbound_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"language": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			"body": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"variables": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"flow_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"phone_number": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"wait_for_approval": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"approval_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rejection_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func outboundTemplateFromResourceData(d *schema.ResourceData) *truora.OutboundTemplate {
+	outbound := &truora.OutboundTemplate{
+		Name:        d.Get("name").(string),
+		Language:    d.Get("language").(string),
+		Body:        d.Get("body").(string),
+		FlowID:      d.Get("flow_id").(string),
+		PhoneNumber: d.Get("phone_number").(string),
+	}
+
+	for _, variable := range d.Get("variables").([]interface{}) {
+		outbound.Variables = append(outbound.Variables, variable.(string))
+	}
+
+	return outbound
+}
+
+// waitForOutboundApproval polls the template until WhatsApp approves or rejects it
+func waitForOutboundApproval(ctx context.Context, client *truora.TruoraClient, outboundID string, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{truora.OutboundApprovalStatusPending},
+		Target:  []string{truora.OutboundApprovalStatusApproved},
+		Refresh: func() (interface{}, string, error) {
+			outbound, err := client.GetOutboundTemplate(ctx, outboundID)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if outbound.ApprovalStatus == truora.OutboundApprovalStatusRejected {
+				return outbound, outbound.ApprovalStatus, fmt.Errorf("outbound template %s was rejected: %s", outboundID, outbound.RejectionReason)
+			}
+
+			return outbound, outbound.ApprovalStatus, nil
+		},
+		Timeout:    timeout,
+		Delay:      outboundApprovalPollInterval,
+		MinTimeout: outboundApprovalPollInterval,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+
+	// the create and update timeouts also end the context, which would only
+	// say the deadline was exceeded
+	var timeoutErr *retry.TimeoutError
+	if errors.As(err, &timeoutErr) || errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("outbound template %s wasn't approved within %s: %w", outboundID, timeout, err)
+	}
+
+	return err
+}
+
+func resourceWhatsAppOutboundTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	resp, err := client.CreateOutboundTemplate(ctx, outboundTemplateFromResourceData(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.OutboundID)
+
+	if d.Get("wait_for_approval").(bool) {
+		if err := waitForOutboundApproval(ctx, client, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceWhatsAppOutboundTemplateRead(ctx, d, m)
+}
+
+func resourceWhatsAppOutboundTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	outbound, err := client.GetOutboundTemplate(ctx, d.Id())
+	if errors.Is(err, truora.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("outbound_id", outbound.OutboundID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("name", outbound.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("language", outbound.Language); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("body", outbound.Body); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("variables", outbound.Variables); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("flow_id", outbound.FlowID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("phone_number", outbound.PhoneNumber); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("approval_status", outbound.ApprovalStatus); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("rejection_reason", outbound.RejectionReason); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceWhatsAppOutboundTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	_, err := client.UpdateOutboundTemplate(ctx, d.Id(), outboundTemplateFromResourceData(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// edited templates go through the approval process again
+	if d.Get("wait_for_approval").(bool) && d.HasChanges("body", "variables") {
+		if err := waitForOutboundApproval(ctx, client, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceWhatsAppOutboundTemplateRead(ctx, d, m)
+}
+
+func resourceWhatsAppOutboundTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*truora.TruoraClient)
+
+	err := client.DeleteOutboundTemplate(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package truora
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+func TestAccResourceWhatsAppOutboundTemplate_basic(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	var outboundID string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckOutboundTemplateDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceOutboundTemplateConfig("Hi {{1}}", false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("truora_whatsapp_outbound_template.test", "id", "truora_whatsapp_outbound_template.test", "outbound_id"),
+					resource.TestCheckResourceAttr("truora_whatsapp_outbound_template.test", "approval_status", truora.OutboundApprovalStatusPending),
+					resource.TestCheckResourceAttr("truora_whatsapp_outbound_template.test", "variables.#", "1"),
+					func(s *terraform.State) error {
+						outboundID = s.RootModule().Resources["truora_whatsapp_outbound_template.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccResourceOutboundTemplateConfig("Hello {{1}}", false, ""),
+				Check: func(s *terraform.State) error {
+					rs := s.RootModule().Resources["truora_whatsapp_outbound_template.test"]
+					if rs.Primary.ID != outboundID {
+						return fmt.Errorf("expected outbound %s to be updated, got %s", outboundID, rs.Primary.ID)
+					}
+
+					outbound, ok := server.OutboundTemplate(rs.Primary.ID)
+					if !ok || outbound.Body != "Hello {{1}}" {
+						return fmt.Errorf("expected the body to be updated, got %+v", outbound)
+					}
+
+					return nil
+				},
+			},
+			{
+				ResourceName:            "truora_whatsapp_outbound_template.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_approval"},
+			},
+		},
+	})
+}
+
+func TestAccResourceWhatsAppOutboundTemplate_waitForApproval(t *testing.T) {
+	server := testAccFakeServer(t)
+	testAccFastOutboundApproval(t)
+
+	server.SetOutboundReview(truoratest.OutboundReview{Reads: 2, Status: truora.OutboundApprovalStatusApproved})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckOutboundTemplateDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceOutboundTemplateConfig("Hi {{1}}", true, ""),
+				Check:  resource.TestCheckResourceAttr("truora_whatsapp_outbound_template.test", "approval_status", truora.OutboundApprovalStatusApproved),
+			},
+			{
+				// edited templates are approved again
+				Config: testAccResourceOutboundTemplateConfig("Hello {{1}}", true, ""),
+				Check:  resource.TestCheckResourceAttr("truora_whatsapp_outbound_template.test", "approval_status", truora.OutboundApprovalStatusApproved),
+			},
+		},
+	})
+}
+
+func TestAccResourceWhatsAppOutboundTemplate_rejected(t *testing.T) {
+	server := testAccFakeServer(t)
+	testAccFastOutboundApproval(t)
+
+	server.SetOutboundReview(truoratest.OutboundReview{
+		Reads:           1,
+		Status:          truora.OutboundApprovalStatusRejected,
+		RejectionReason: "INVALID_FORMAT",
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckOutboundTemplateDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceOutboundTemplateConfig("Hi {{1}}", true, ""),
+				ExpectError: regexp.MustCompile(`was rejected: INVALID_FORMAT`),
+			},
+		},
+	})
+}
+
+func TestAccResourceWhatsAppOutboundTemplate_approvalTimeout(t *testing.T) {
+	server := testAccFakeServer(t)
+	testAccFastOutboundApproval(t)
+
+	// never reviewed
+	server.SetOutboundReview(truoratest.OutboundReview{})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckOutboundTemplateDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceOutboundTemplateConfig("Hi {{1}}", true, "1s"),
+				ExpectError: regexp.MustCompile(`wasn't approved within 1s`),
+			},
+		},
+	})
+}
+
+// testAccFastOutboundApproval polls templates often, so tests waiting for
+// approval don't take minutes
+func testAccFastOutboundApproval(t *testing.T) {
+	interval := outboundApprovalPollInterval
+	outboundApprovalPollInterval = 10 * time.Millisecond
+
+	t.Cleanup(func() {
+		outboundApprovalPollInterval = interval
+	})
+}
+
+func testAccCheckOutboundTemplateDestroy(server *truoratest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "truora_whatsapp_outbound_template" {
+				continue
+			}
+
+			if _, ok := server.OutboundTemplate(rs.Primary.ID); ok {
+				return fmt.Errorf("outbound %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceOutboundTemplateConfig(body string, waitForApproval bool, createTimeout string) string {
+	timeouts := ""
+	if createTimeout != "" {
+		timeouts = fmt.Sprintf(`
+  timeouts {
+    create = %q
+  }
+`, createTimeout)
+	}
+
+	return fmt.Sprintf(`
+resource "truora_whatsapp_outbound_template" "test" {
+  name              = "welcome"
+  language          = "es"
+  body              = %q
+  variables         = ["name"]
+  phone_number      = "+573001234567"
+  wait_for_approval = %t
+%s}
+`, body, waitForApproval, timeouts)
+}
@@ -0,0 +1,158 @@
+package truora
+
+import (
+	"context"
+	"os"
+	"time"
+
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "terraform-provider-truora/truora"
+
+// tracingBatchTimeout is short because Terraform stops the provider as soon
+// as it is done with it
+const tracingBatchTimeout = time.Second
+
+// otlpEndpointEnvironmentVariables enable tracing when any of them is set, the
+// exporter reads the rest of its settings from the standard OTEL_ variables
+var otlpEndpointEnvironmentVariables = []string{"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"}
+
+type crudFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+// StartTracing exports spans over OTLP/HTTP when an OTLP endpoint is set in the
+// environment, otherwise tracing stays disabled. The returned function
+// flushes pending spans and must be called before exiting.
+func StartTracing(ctx context.Context) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	enabled := false
+	for _, name := range otlpEndpointEnvironmentVariables {
+		if os.Getenv(name) != "" {
+			enabled = true
+		}
+	}
+
+	if !enabled {
+		return noop, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("terraform-provider-truora"),
+		semconv.ServiceVersion(Version),
+	))
+	if err != nil {
+		return noop, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(tracingBatchTimeout)),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return tracerProvider.Shutdown, nil
+}
+
+// environmentCarrier reads the W3C trace context from TRACEPARENT and
+// TRACESTATE, the way CI systems pass it to the processes of a job
+type environmentCarrier struct{}
+
+func (environmentCarrier) Get(key string) string {
+	switch key {
+	case "traceparent":
+		return os.Getenv("TRACEPARENT")
+	case "tracestate":
+		return os.Getenv("TRACESTATE")
+	}
+
+	return ""
+}
+
+func (environmentCarrier) Set(string, string) {}
+
+func (environmentCarrier) Keys() []string {
+	return []string{"traceparent", "tracestate"}
+}
+
+// startSpan starts a span, spans without a parent are attached to the trace
+// in TRACEPARENT
+func startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = propagation.TraceContext{}.Extract(ctx, environmentCarrier{})
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// startOperationSpan starts the span of an operation on a resource, data
+// source or ephemeral resource, named after them
+func startOperationSpan(ctx context.Context, name, operation string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return startSpan(ctx, name+"."+operation, append([]attribute.KeyValue{
+		attribute.String("terraform.resource_type", name),
+		attribute.String("terraform.operation", operation),
+	}, attributes...)...)
+}
+
+// endFrameworkSpan ends the span of a framework operation, marking it failed
+// when the operation returned errors
+func endFrameworkSpan(span trace.Span, diags fwdiag.Diagnostics) {
+	if errs := diags.Errors(); len(errs) > 0 {
+		span.SetStatus(codes.Error, errs[0].Summary())
+	}
+
+	span.End()
+}
+
+// withSpan runs f in a span named after the resource and operation
+func withSpan(name, operation string, f crudFunc) crudFunc {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		ctx, span := startOperationSpan(ctx, name, operation, attribute.String("terraform.resource_id", d.Id()))
+		defer span.End()
+
+		diags := f(ctx, d, m)
+
+		for _, d := range diags {
+			if d.Severity == diag.Error {
+				span.SetStatus(codes.Error, d.Summary)
+				break
+			}
+		}
+
+		return diags
+	}
+}
+
+// instrumentResource wraps the CRUD functions of a resource or data source in
+// spans
+func instrumentResource(name string, r *schema.Resource) *schema.Resource {
+	r.CreateContext = withSpan(name, "create", r.CreateContext)
+	r.ReadContext = withSpan(name, "read", r.ReadContext)
+	r.UpdateContext = withSpan(name, "update", r.UpdateContext)
+	r.DeleteContext = withSpan(name, "delete", r.DeleteContext)
+
+	return r
+}
@@ -0,0 +1,68 @@
+package truora
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	truora "terraform-provider-truora/truora/client"
+	"terraform-provider-truora/truora/client/truoratest"
+)
+
+// testSpanRecorder records the spans of the test in place of the global
+// tracer provider
+func testSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func TestWithSpanAttachesToTraceparent(t *testing.T) {
+	recorder := testSpanRecorder(t)
+
+	t.Setenv("TRACEPARENT", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	server := truoratest.NewServer()
+	defer server.Close()
+
+	flow := server.AddFlow(&truora.IdentityProcessFlow{Name: "traced", Type: "permanent"})
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := withSpan("truora_flow", "read", func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		_, err := m.(*truora.TruoraClient).GetFlow(ctx, flow.FlowID)
+		return diag.FromErr(err)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceFlow().Schema, map[string]interface{}{})
+	if diags := read(context.Background(), d, client); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	httpSpan, readSpan := spans[0], spans[1]
+
+	if readSpan.Name() != "truora_flow.read" || readSpan.Parent().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("expected truora_flow.read in the TRACEPARENT trace, got %s in %s", readSpan.Name(), readSpan.Parent().TraceID())
+	}
+
+	if httpSpan.Parent().SpanID() != readSpan.SpanContext().SpanID() {
+		t.Fatalf("expected %s to be a child of %s", httpSpan.Name(), readSpan.Name())
+	}
+}
@@ -0,0 +1,102 @@
+package truora
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	businessHoursClockLayout = "15:04"
+
+	minFollowUpDelay = 1
+)
+
+var (
+	flowLanguages         = []string{"es", "en", "pt"}
+	customMessageStatuses = []string{"success", "failure", "pending"}
+
+	validateFlowLanguage         = validation.ToDiagFunc(validation.StringInSlice(flowLanguages, false))
+	validateCustomMessageStatus  = validation.ToDiagFunc(validation.StringInSlice(customMessageStatuses, false))
+	validateFollowUpDelay        = validation.ToDiagFunc(validation.IntAtLeast(minFollowUpDelay))
+	validateBusinessHoursSetting = validation.ToDiagFunc(validateBusinessHours)
+	validateVerificationPosition = validation.ToDiagFunc(validation.IntAtLeast(0))
+)
+
+// parseBusinessHours accepts either a full RFC3339 timestamp or a HH:MM clock time.
+func parseBusinessHours(s string) (*time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse(businessHoursClockLayout, s)
+	if err != nil {
+		return nil, fmt.Errorf("%q is neither an RFC3339 timestamp nor a HH:MM time", s)
+	}
+
+	return &t, nil
+}
+
+func validateBusinessHours(v interface{}, k string) ([]string, []error) {
+	if _, err := parseBusinessHours(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+
+	return nil, nil
+}
+
+// validateFlowConfig checks the rules that involve more than one config
+// attribute and can't be expressed in the schema. It runs on the merged
+// document, so it also covers values set in source_json and override_json.
+func validateFlowConfig(config map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	businessHours := map[string]*time.Time{}
+	for _, key := range []string{"start_business_hours", "end_business_hours"} {
+		v, ok := config[key]
+		if !ok {
+			continue
+		}
+
+		s, _ := v.(string)
+
+		t, err := parseBusinessHours(s)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid business hours",
+				Detail:        fmt.Sprintf("%s: %s", key, err),
+				AttributePath: cty.GetAttrPath("config").IndexInt(0).GetAttr(key),
+			})
+
+			continue
+		}
+
+		businessHours[key] = t
+	}
+
+	start, end := businessHours["start_business_hours"], businessHours["end_business_hours"]
+	if start == nil || end == nil {
+		return diags
+	}
+
+	// timestamps can be in different zones, the clock times are compared in
+	// the zone of the start
+	if clockMinutes(*start) >= clockMinutes(end.In(start.Location())) {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid business hours",
+			Detail:        "start_business_hours must be before end_business_hours",
+			AttributePath: cty.GetAttrPath("config").IndexInt(0).GetAttr("start_business_hours"),
+		})
+	}
+
+	return diags
+}
+
+func clockMinutes(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
@@ -0,0 +1,73 @@
+package truora
+
+import (
+	"testing"
+)
+
+func TestValidateFlowConfig(t *testing.T) {
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		errors int
+	}{
+		{
+			name:   "no business hours",
+			config: map[string]interface{}{"lang": "es"},
+		},
+		{
+			name: "clock times in order",
+			config: map[string]interface{}{
+				"start_business_hours": "09:00",
+				"end_business_hours":   "18:00",
+			},
+		},
+		{
+			name: "clock times reversed",
+			config: map[string]interface{}{
+				"start_business_hours": "18:00",
+				"end_business_hours":   "09:00",
+			},
+			errors: 1,
+		},
+		{
+			name: "timestamps in different zones in order",
+			config: map[string]interface{}{
+				"start_business_hours": "2024-01-01T09:00:00-05:00",
+				"end_business_hours":   "2024-01-01T16:00:00+01:00",
+			},
+		},
+		{
+			name: "timestamps in different zones reversed",
+			config: map[string]interface{}{
+				"start_business_hours": "2024-01-01T09:00:00-05:00",
+				"end_business_hours":   "2024-01-01T14:00:00+01:00",
+			},
+			errors: 1,
+		},
+		{
+			name: "unparseable start",
+			config: map[string]interface{}{
+				"start_business_hours": "9am",
+				"end_business_hours":   "18:00",
+			},
+			errors: 1,
+		},
+		{
+			name: "not a string",
+			config: map[string]interface{}{
+				"start_business_hours": 9,
+				"end_business_hours":   "18:00",
+			},
+			errors: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := validateFlowConfig(tc.config)
+			if len(diags) != tc.errors {
+				t.Fatalf("expected %d errors, got %v", tc.errors, diags)
+			}
+		})
+	}
+}